@@ -1,10 +1,11 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	relgen "github.com/bajankristof/relgen/internal"
+	"github.com/bajankristof/relgen/internal/semver"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/urfave/cli/v2"
 	"os"
 	"path"
@@ -16,20 +17,30 @@ var (
 )
 
 var (
-	ConfigFlag        = "config"
-	PreReleaseFlag    = "pre-release"
-	BuildMetadataFlag = "build-metadata"
-	VersionPrefixFlag = "version-prefix"
-	DryRunFlag        = "dry-run"
+	ConfigFlag             = "config"
+	PreReleaseFlag         = "pre-release"
+	SanitizePreReleaseFlag = "sanitize-pre-release"
+	BuildMetadataFlag      = "build-metadata"
+	VersionPrefixFlag      = "version-prefix"
+	DryRunFlag             = "dry-run"
+	TagFlag                = "tag"
+	CommitFlag             = "commit"
+	PushFlag               = "push"
+	FormatFlag             = "format"
+	QuietFlag              = "quiet"
+	FailOnNoReleaseFlag    = "fail-on-no-release"
 )
 
 func Start() error {
-	app := &cli.App{
+	return newApp().Run(os.Args)
+}
+
+func newApp() *cli.App {
+	return &cli.App{
 		Name:        "RelGen",
 		HelpName:    "relgen",
 		Version:     Version,
 		Description: Description,
-		Commands:    []*cli.Command{},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:      ConfigFlag,
@@ -43,6 +54,11 @@ func Start() error {
 				Usage: "generate a pre-release version with the specified tag",
 				Value: "",
 			},
+			&cli.BoolFlag{
+				Name:  SanitizePreReleaseFlag,
+				Usage: "convert the pre-release tag into a valid semver identifier (e.g.: feature/foo -> feature-foo)",
+				Value: false,
+			},
 			&cli.StringFlag{
 				Name:  BuildMetadataFlag,
 				Usage: "set the build-metadata of the generated version",
@@ -55,58 +71,436 @@ func Start() error {
 			},
 			&cli.BoolFlag{
 				Name:  DryRunFlag,
-				Usage: "print the generated release to the standard output",
+				Usage: "print the generated release to the standard output (without writing outputs or publishing)",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  CommitFlag,
+				Usage: "commit the written outputs as a release commit (e.g.: chore(release): 1.2.3)",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  TagFlag,
+				Usage: "create the release tag at the current commit after writing the outputs",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  PushFlag,
+				Usage: "push the release commit and tag to the remote",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:    FormatFlag,
+				Usage:   "print the generated release in the specified format (json, yaml, env or text)",
+				Value:   relgen.FormatJSON,
+				Aliases: []string{"f"},
+			},
+			&cli.BoolFlag{
+				Name:    QuietFlag,
+				Usage:   "do not print the generated release or the nothing to release notice (errors are still printed)",
+				Value:   false,
+				Aliases: []string{"q"},
+			},
+			&cli.BoolFlag{
+				Name:  FailOnNoReleaseFlag,
+				Usage: fmt.Sprintf("exit with code %d when there is nothing to release", ExitNothingToRelease),
 				Value: false,
 			},
 		},
-		Action: func(ctx *cli.Context) error {
-			cwd, err := os.Getwd()
-			if err != nil {
-				return err
-			}
+		Commands: []*cli.Command{
+			{
+				Name:   "describe",
+				Usage:  "generate a unique development version for the current commit (e.g.: 1.4.0-dev.17+g3fa2b1c)",
+				Action: describe,
+			},
+			{
+				Name:   "pr",
+				Usage:  "open or update a release pull request, or tag the release when a release pull request was merged",
+				Action: pullRequest,
+			},
+			{
+				Name:   "lint",
+				Usage:  "check the commits since the last release and suggest fixes for unrecognized types",
+				Action: lint,
+			},
+		},
+		Action:         release,
+		ExitErrHandler: func(*cli.Context, error) {},
+	}
+}
 
-			cfg, err := relgen.ReadConfig(path.Join(cwd, ctx.String(ConfigFlag)))
-			if err != nil {
-				return err
-			}
+func release(ctx *cli.Context) error {
+	cfg, builder, err := newReleaseBuilder(ctx)
+	if err != nil {
+		return err
+	}
 
-			if ctx.IsSet(PreReleaseFlag) {
-				cfg.PreRelease = ctx.String(PreReleaseFlag)
-			}
+	rel, err := builder.Build()
+	if err != nil {
+		return repositoryError(err)
+	}
 
-			if ctx.IsSet(BuildMetadataFlag) {
-				cfg.BuildMetadata = ctx.String(BuildMetadataFlag)
-			}
+	if len(rel.Changelog) < 1 {
+		if err = cfg.CI.Write(rel, false); err != nil {
+			return err
+		}
 
-			if ctx.IsSet(VersionPrefixFlag) {
-				cfg.VersionPrefix = ctx.Bool(VersionPrefixFlag)
-			}
+		return nothingToRelease(ctx, rel)
+	}
 
-			repo, err := git.PlainOpen(cwd)
-			if err != nil {
-				return err
-			}
+	base, err := builder.ReadHead()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	if err = emit(ctx, cfg, rel); err != nil {
+		return err
+	}
+
+	if ctx.Bool(DryRunFlag) {
+		return dryRun(ctx, cfg, builder, rel)
+	}
 
-			builder := relgen.NewReleaseBuilder(repo, cfg)
-			rel, err := builder.Build()
+	if ctx.Bool(CommitFlag) {
+		if _, err = builder.CommitOutputs(rel, cfg.Outputs.Paths()); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Bool(TagFlag) {
+		if err = tag(cfg, builder, rel); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Bool(PushFlag) {
+		var refs []plumbing.ReferenceName
+		if ctx.Bool(CommitFlag) {
+			branch, err := builder.ReadBranch()
 			if err != nil {
 				return err
 			}
 
-			if len(rel.Changelog) < 1 {
-				return nil
-			}
+			refs = append(refs, branch)
+		}
 
-			output, _ := json.Marshal(rel)
-			fmt.Println(string(output))
+		if ctx.Bool(TagFlag) {
+			refs = append(refs, plumbing.NewTagReferenceName(rel.Tag))
+		}
 
-			if ctx.Bool(DryRunFlag) {
-				return nil
-			}
+		if err = builder.Push(base.Hash, refs); err != nil {
+			return err
+		}
+	}
 
-			return cfg.Outputs.Execute(rel)
-		},
+	if err = cfg.CI.Write(rel, true); err != nil {
+		return err
+	}
+
+	return publish(cfg, builder, rel)
+}
+
+func pullRequest(ctx *cli.Context) (err error) {
+	cfg, builder, err := newReleaseBuilder(ctx)
+	if err != nil {
+		return err
+	}
+
+	merged, err := builder.ReadMergedRelease()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	if merged != nil {
+		return releaseMerged(ctx, cfg, builder, merged)
+	}
+
+	rel, err := builder.Build()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	if err = cfg.CI.Write(rel, false); err != nil {
+		return err
+	}
+
+	if len(rel.Changelog) < 1 {
+		return nothingToRelease(ctx, rel)
+	}
+
+	if ctx.Bool(DryRunFlag) {
+		return emit(ctx, cfg, rel)
+	}
+
+	base, err := builder.ReadBranch()
+	if err != nil {
+		return err
+	}
+
+	restore, err := builder.CheckoutReleaseBranch()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if restoreErr := restore(); err == nil {
+			err = restoreErr
+		}
+	}()
+
+	if err = emit(ctx, cfg, rel); err != nil {
+		return err
+	}
+
+	if _, err = builder.CommitOutputs(rel, cfg.Outputs.Paths()); err != nil {
+		return err
+	}
+
+	branch := cfg.PullRequest.BranchName()
+	if err = builder.ForcePush(branch); err != nil {
+		return err
+	}
+
+	title, err := cfg.PullRequest.RenderTitle(rel)
+	if err != nil {
+		return err
+	}
+
+	body, err := cfg.PullRequest.RenderBody(rel)
+	if err != nil {
+		return err
+	}
+
+	pr := &relgen.PullRequest{Head: branch.Short(), Base: base.Short(), Title: title, Body: body}
+	return cfg.Publishers.PublishPullRequest(builder.Repository, rel, pr)
+}
+
+func releaseMerged(ctx *cli.Context, cfg *relgen.Config, builder *relgen.ReleaseBuilder, version *semver.Version) error {
+	head, err := builder.ReadHead()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	current, err := builder.ReadCurrentVersion()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	rel, err := builder.Build()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	if current != nil && current.IsReference(head.Hash) {
+		return nothingToRelease(ctx, rel)
+	}
+
+	rel.Version = version
+	if rel.Tag, err = cfg.FormatTag(version); err != nil {
+		return err
+	}
+
+	if err = printRelease(ctx, rel); err != nil {
+		return err
+	}
+
+	if ctx.Bool(DryRunFlag) {
+		return dryRun(ctx, cfg, builder, rel)
+	}
+
+	if err = tag(cfg, builder, rel); err != nil {
+		return err
+	}
+
+	if ctx.Bool(PushFlag) {
+		if err = builder.Push(head.Hash, []plumbing.ReferenceName{plumbing.NewTagReferenceName(rel.Tag)}); err != nil {
+			return err
+		}
+	}
+
+	if err = cfg.CI.Write(rel, true); err != nil {
+		return err
+	}
+
+	return publish(cfg, builder, rel)
+}
+
+func tag(cfg *relgen.Config, builder *relgen.ReleaseBuilder, rel *relgen.Release) error {
+	if err := builder.CreateTag(rel); err != nil {
+		return err
+	}
+
+	return relgen.RunHooks(cfg.Hooks.PostTag, rel)
+}
+
+func publish(cfg *relgen.Config, builder *relgen.ReleaseBuilder, rel *relgen.Release) error {
+	if err := cfg.Publishers.Publish(builder.Repository, rel); err != nil {
+		return err
+	}
+
+	return cfg.Webhooks.Send(rel)
+}
+
+func describe(ctx *cli.Context) error {
+	cfg, builder, err := newReleaseBuilder(ctx)
+	if err != nil {
+		return err
+	}
+
+	rel, err := builder.Describe()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	if err = cfg.CI.Write(rel, false); err != nil {
+		return err
+	}
+
+	return emit(ctx, cfg, rel)
+}
+
+func lint(ctx *cli.Context) error {
+	_, builder, err := newReleaseBuilder(ctx)
+	if err != nil {
+		return err
+	}
+
+	issues, err := builder.Lint()
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d issue(s) in the commits since the last release", len(issues))
+	}
+
+	return nil
+}
+
+func newReleaseBuilder(ctx *cli.Context) (*relgen.Config, *relgen.ReleaseBuilder, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := relgen.ReadConfig(path.Join(cwd, ctx.String(ConfigFlag)))
+	if err != nil {
+		return nil, nil, configError(err)
+	}
+
+	if ctx.IsSet(PreReleaseFlag) {
+		cfg.PreRelease = ctx.String(PreReleaseFlag)
+	}
+
+	if ctx.IsSet(SanitizePreReleaseFlag) {
+		cfg.SanitizePreRelease = ctx.Bool(SanitizePreReleaseFlag)
+	}
+
+	if ctx.IsSet(BuildMetadataFlag) {
+		cfg.BuildMetadata = ctx.String(BuildMetadataFlag)
+	}
+
+	if ctx.IsSet(VersionPrefixFlag) {
+		cfg.VersionPrefix = ctx.Bool(VersionPrefixFlag)
+	}
+
+	err = cfg.Check()
+	if err != nil {
+		return nil, nil, configError(err)
+	}
+
+	switch ctx.String(FormatFlag) {
+	case relgen.FormatJSON, relgen.FormatYAML, relgen.FormatEnv, relgen.FormatText:
+	default:
+		return nil, nil, configError(fmt.Errorf("unrecognized format \"%s\"", ctx.String(FormatFlag)))
+	}
+
+	repo, err := git.PlainOpen(cwd)
+	if err != nil {
+		return nil, nil, repositoryError(err)
+	}
+
+	return cfg, relgen.NewReleaseBuilder(repo, cfg), nil
+}
+
+func emit(ctx *cli.Context, cfg *relgen.Config, rel *relgen.Release) error {
+	if err := printRelease(ctx, rel); err != nil {
+		return err
+	}
+
+	if ctx.Bool(DryRunFlag) {
+		return nil
+	}
+
+	if err := relgen.RunHooks(cfg.Hooks.PreOutputs, rel); err != nil {
+		return err
+	}
+
+	if err := cfg.Outputs.Execute(rel); err != nil {
+		return err
+	}
+
+	return relgen.RunHooks(cfg.Hooks.PostOutputs, rel)
+}
+
+func printRelease(ctx *cli.Context, rel *relgen.Release) error {
+	if ctx.Bool(QuietFlag) {
+		return nil
+	}
+
+	output, err := relgen.FormatRelease(rel, ctx.String(FormatFlag))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(ctx.App.Writer, output)
+	return nil
+}
+
+func dryRun(ctx *cli.Context, cfg *relgen.Config, builder *relgen.ReleaseBuilder, rel *relgen.Release) error {
+	if err := cfg.CI.Write(rel, false); err != nil {
+		return err
+	}
+
+	if !ctx.Bool(QuietFlag) {
+		issues, err := builder.Lint()
+		if err != nil {
+			return repositoryError(err)
+		}
+
+		for _, issue := range issues {
+			fmt.Fprintln(ctx.App.ErrWriter, "relgen: warning: "+issue)
+		}
+	}
+
+	return printWebhooks(ctx, cfg, rel)
+}
+
+func printWebhooks(ctx *cli.Context, cfg *relgen.Config, rel *relgen.Release) error {
+	if ctx.Bool(QuietFlag) {
+		return nil
+	}
+
+	return cfg.Webhooks.Print(ctx.App.ErrWriter, rel)
+}
+
+func nothingToRelease(ctx *cli.Context, rel *relgen.Release) error {
+	if !ctx.Bool(QuietFlag) {
+		message := "nothing to release"
+		if rel.Statistics.PreviousTag != "" {
+			message += " since " + rel.Statistics.PreviousTag
+		}
+
+		fmt.Fprintln(ctx.App.ErrWriter, "relgen: "+message)
+	}
+
+	if ctx.Bool(FailOnNoReleaseFlag) {
+		return &ExitError{Code: ExitNothingToRelease}
 	}
 
-	return app.Run(os.Args)
+	return nil
 }
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/go-git/go-git/v5"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runApp(t *testing.T, args ...string) (string, string, error) {
+	return runAppWithCommits(t, []string{"feat: foo"}, args...)
+}
+
+func runAppWithCommits(t *testing.T, messages []string, args ...string) (string, string, error) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("git.PlainInit(%s, false) = (%v, %v), expected error to be <nil>", dir, repo, err)
+	}
+
+	for _, message := range messages {
+		mocking.CommitFiles(repo, message, map[string]string{"main.go": message})
+	}
+
+	cwd, _ := os.Getwd()
+	defer func() { _ = os.Chdir(cwd) }()
+	if err = os.Chdir(dir); err != nil {
+		t.Fatalf("os.Chdir(%s) = %v, expected <nil>", dir, err)
+	}
+
+	t.Setenv("GITHUB_OUTPUT", filepath.Join(dir, "github-output"))
+	t.Setenv("GITHUB_STEP_SUMMARY", filepath.Join(dir, "github-summary"))
+	output := &bytes.Buffer{}
+	app := newApp()
+	app.Writer = output
+	app.ErrWriter = output
+	err = app.Run(append([]string{"relgen"}, args...))
+	return dir, output.String(), err
+}
+
+func TestDescribe(t *testing.T) {
+	dir, stdout, err := runApp(t, "--format", "json", "describe")
+	if err != nil {
+		t.Fatalf("relgen describe = %v, expected <nil>", err)
+	}
+
+	result := map[string]any{}
+	decodeErr := json.Unmarshal([]byte(stdout), &result)
+	version, _ := os.ReadFile(filepath.Join(dir, "version.txt"))
+	outputs, _ := os.ReadFile(filepath.Join(dir, "github-output"))
+
+	switch true {
+	case decodeErr != nil:
+		t.Fatalf("relgen describe printed %q, expected JSON, got error %v", stdout, decodeErr)
+	case !strings.Contains(result["version"].(string), "-dev.1+g"):
+		t.Fatalf("relgen describe printed version %v, expected a development version", result["version"])
+	case string(version) != result["version"]:
+		t.Fatalf("relgen describe wrote version.txt %q, expected %q", version, result["version"])
+	case !strings.Contains(string(outputs), "version="+result["version"].(string)+"\n") || !strings.Contains(string(outputs), "released=false\n"):
+		t.Fatalf("relgen describe wrote CI outputs %q, expected the development version with released=false", outputs)
+	}
+}
+
+func TestDescribe_DryRun(t *testing.T) {
+	dir, stdout, err := runApp(t, "--dry-run", "--format", "text", "describe")
+	_, statErr := os.Stat(filepath.Join(dir, "version.txt"))
+
+	switch true {
+	case err != nil:
+		t.Fatalf("relgen describe --dry-run = %v, expected <nil>", err)
+	case !strings.Contains(stdout, "-dev.1+g"):
+		t.Fatalf("relgen describe --dry-run printed %q, expected a development version", stdout)
+	case statErr == nil:
+		t.Fatalf("relgen describe --dry-run wrote version.txt, expected the outputs to be skipped")
+	}
+}
+
+func TestRelease_CIOutputs(t *testing.T) {
+	dir, _, err := runApp(t, "--dry-run")
+	outputs, _ := os.ReadFile(filepath.Join(dir, "github-output"))
+	_, summaryErr := os.Stat(filepath.Join(dir, "github-summary"))
+
+	switch true {
+	case err != nil:
+		t.Fatalf("relgen --dry-run = %v, expected <nil>", err)
+	case !strings.Contains(string(outputs), "released=false\n"):
+		t.Fatalf("relgen --dry-run wrote CI outputs %q, expected released=false", outputs)
+	case summaryErr == nil:
+		t.Fatalf("relgen --dry-run wrote the job summary, expected it to be skipped")
+	}
+
+	dir, _, err = runApp(t, "--quiet")
+	outputs, _ = os.ReadFile(filepath.Join(dir, "github-output"))
+	_, summaryErr = os.Stat(filepath.Join(dir, "github-summary"))
+
+	switch true {
+	case err != nil:
+		t.Fatalf("relgen = %v, expected <nil>", err)
+	case !strings.Contains(string(outputs), "released=true\n"):
+		t.Fatalf("relgen wrote CI outputs %q, expected released=true", outputs)
+	case summaryErr != nil:
+		t.Fatalf("relgen did not write the job summary, expected the changelog in it")
+	}
+}
+
+func TestRelease_DryRunWarnings(t *testing.T) {
+	_, output, err := runAppWithCommits(t, []string{"feat: foo", "fature: typo"}, "--dry-run", "--format", "text")
+
+	switch true {
+	case err != nil:
+		t.Fatalf("relgen --dry-run = %v, expected <nil>", err)
+	case !strings.Contains(output, "relgen: warning: ") || !strings.Contains(output, ": `fature` looks like `feat`\n"):
+		t.Fatalf("relgen --dry-run printed %q, expected a warning with a type suggestion", output)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+)
+
+const (
+	ExitReleased         = 0
+	ExitFailure          = 1
+	ExitNothingToRelease = 2
+	ExitConfigError      = 3
+	ExitRepositoryError  = 4
+)
+
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (err *ExitError) Error() string {
+	if err.Err == nil {
+		return ""
+	}
+
+	return err.Err.Error()
+}
+
+func (err *ExitError) Unwrap() error {
+	return err.Err
+}
+
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitReleased
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	return ExitFailure
+}
+
+func configError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &ExitError{Code: ExitConfigError, Err: err}
+}
+
+func repositoryError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &ExitError{Code: ExitRepositoryError, Err: err}
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	err := fmt.Errorf("reading config: %w", configError(errors.New("invalid")))
+
+	switch true {
+	case ExitCode(nil) != ExitReleased:
+		t.Fatalf(`ExitCode(<nil>) = %d, expected %d`, ExitCode(nil), ExitReleased)
+	case ExitCode(errors.New("failed")) != ExitFailure:
+		t.Fatalf(`ExitCode("failed") = %d, expected %d`, ExitCode(errors.New("failed")), ExitFailure)
+	case ExitCode(err) != ExitConfigError || err.Error() != "reading config: invalid":
+		t.Fatalf(`ExitCode(%v) = %d, expected %d`, err, ExitCode(err), ExitConfigError)
+	case ExitCode(repositoryError(errors.New("not a repository"))) != ExitRepositoryError:
+		t.Fatalf(`ExitCode("not a repository") = %d, expected %d`, ExitCode(repositoryError(errors.New("not a repository"))), ExitRepositoryError)
+	case ExitCode(&ExitError{Code: ExitNothingToRelease}) != ExitNothingToRelease:
+		t.Fatalf(`ExitCode(&ExitError{Code: %d}) != %d`, ExitNothingToRelease, ExitNothingToRelease)
+	case configError(nil) != nil || repositoryError(nil) != nil:
+		t.Fatalf(`configError(<nil>) and repositoryError(<nil>), expected <nil>`)
+	}
+}
@@ -8,10 +8,18 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
 )
 
+const DevelopmentPreReleaseTag = "dev"
+
 var errBreak = errors.New("break")
 
+type change struct {
+	*conventionalcommits.ConventionalCommit
+	spec *ChangeSpec
+}
+
 type ReleaseBuilder struct {
 	bump       string
 	Repository injection.Repository
@@ -33,33 +41,43 @@ func (builder *ReleaseBuilder) Build() (*Release, error) {
 }
 
 func (builder *ReleaseBuilder) BuildSince(version *semver.Version) (*Release, error) {
-	commits, err := builder.Repository.Log(&git.LogOptions{All: true})
-	if err != nil {
-		return nil, err
-	}
-
 	rel := NewRelease(builder.NewReleaseVersion(version))
-	iter := injection.NonMergeCommitIter{MaxDepth: 1}
-	err = iter.ForEach(commits, func(commit *object.Commit) error {
+	var hashes []plumbing.Hash
+	var changes []*change
+	err := builder.ForEachCommit(func(commit *object.Commit) error {
 		if version != nil && version.IsReference(commit.Hash) {
 			return errBreak
 		}
 
-		cc, err := conventionalcommits.NewConventionalCommit(commit)
+		hashes = append(hashes, commit.Hash)
+		cc, err := builder.NewConventionalCommit(commit)
 		if err != nil {
+			rel.Statistics.PushCommit(commit, false)
 			return nil
 		}
 
-		if cc.HasFooter("relgen-off") {
+		ignored, err := builder.Config.Ignore.Ignores(builder.Repository, cc)
+		if err != nil {
+			return err
+		}
+
+		if ignored || !builder.Config.AllowsScopes(cc) {
+			rel.Statistics.PushIgnored(commit)
 			return nil
 		}
 
+		rel.Statistics.PushCommit(commit, true)
+
 		_, spec := builder.Config.FindChangeSpec(cc)
+		if spec == nil && cc.IsRevert() {
+			spec = &RevertChangeSpec
+		}
+
 		if spec == nil {
 			return nil
 		}
 
-		rel.Push(cc, spec)
+		changes = append(changes, &change{cc, spec})
 		return nil
 	})
 
@@ -67,11 +85,195 @@ func (builder *ReleaseBuilder) BuildSince(version *semver.Version) (*Release, er
 		return nil, err
 	}
 
-	rel.Close(builder.Config.PreRelease, builder.Config.BuildMetadata)
+	if builder.Config.CommitSource == CommitSourceBoth {
+		if changes, err = builder.collapseMerged(changes, hashes); err != nil {
+			return nil, err
+		}
+	}
+
+	if !builder.Config.KeepDuplicates {
+		if changes, err = builder.collapseDuplicates(changes); err != nil {
+			return nil, err
+		}
+	}
+
+	changes = cancelReverts(changes, hashes)
+	for _, change := range changes {
+		rel.Push(change.ConventionalCommit, change.spec)
+	}
+
+	if err = builder.ReadStatistics(rel, version); err != nil {
+		return nil, err
+	}
+
+	if builder.Config.Contributors {
+		mailmap, err := builder.ReadMailmap()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, change := range changes {
+			for _, contributor := range FindContributors(change.ConventionalCommit) {
+				contributor = mailmap.Resolve(contributor)
+				if !builder.Config.ExcludesContributor(contributor) {
+					rel.PushContributor(contributor)
+				}
+			}
+		}
+	}
+
+	metadata, err := builder.RenderBuildMetadata(rel)
+	if err != nil {
+		return nil, err
+	}
+
+	rel.Close(builder.Config.PreRelease, metadata)
+	rel.Tag, err = builder.Config.FormatTag(rel.Version)
+	if err != nil {
+		return nil, err
+	}
 
 	return rel, nil
 }
 
+func (builder *ReleaseBuilder) ForEachCommit(callback func(commit *object.Commit) error) error {
+	switch builder.Config.CommitSource {
+	case CommitSourceMergeSubject, CommitSourceMergeBody:
+		head, err := builder.ReadHead()
+		if err != nil {
+			return err
+		}
+
+		iter := &injection.FirstParentCommitIter{Repository: builder.Repository}
+		return iter.ForEach(head, callback)
+	default:
+		commits, err := builder.Repository.Log(&git.LogOptions{All: true})
+		if err != nil {
+			return err
+		}
+
+		iter := &injection.NonMergeCommitIter{MaxDepth: 1, Merges: builder.Config.CommitSource == CommitSourceBoth}
+		return iter.ForEach(commits, callback)
+	}
+}
+
+func (builder *ReleaseBuilder) NewConventionalCommit(commit *object.Commit) (*conventionalcommits.ConventionalCommit, error) {
+	parser := builder.Config.Parser()
+	var cc *conventionalcommits.ConventionalCommit
+	var err error
+	switch true {
+	case commit.NumParents() < 2:
+		cc, err = parser.NewConventionalCommit(commit)
+	case builder.Config.CommitSource == CommitSourceMergeSubject:
+		cc, err = parser.NewMergeConventionalCommit(commit, false)
+	case builder.Config.CommitSource == CommitSourceMergeBody:
+		cc, err = parser.NewMergeConventionalCommit(commit, true)
+	default:
+		cc, err = parser.NewMergeConventionalCommit(commit, false)
+		if err != nil {
+			cc, err = parser.NewMergeConventionalCommit(commit, true)
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cc.Type = builder.Config.NormalizeType(cc.Type)
+	cc.Issues = builder.Config.FindIssues(cc)
+	return cc, nil
+}
+
+func (builder *ReleaseBuilder) Describe() (*Release, error) {
+	rel, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if rel.CommitCount() < 1 {
+		return rel, nil
+	}
+
+	head, err := builder.ReadHead()
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := rel.Version.Metadata
+	if rel.Bump == semver.NONE {
+		rel.Version.BumpPatch()
+	}
+
+	rel.Version.WithPreReleaseTag(DevelopmentPreReleaseTag)
+	rel.Version.WithPreReleaseNumber(int64(rel.CommitCount()))
+	rel.Version.Metadata = metadata
+	if head != nil && metadata == "" {
+		rel.Version.Metadata = "g" + head.Hash.String()[:7]
+	}
+
+	rel.Tag, err = builder.Config.FormatTag(rel.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return rel, nil
+}
+
+func (builder *ReleaseBuilder) CreateTag(rel *Release) error {
+	head, err := builder.Repository.Head()
+	if err != nil {
+		return err
+	}
+
+	_, err = builder.Repository.CreateTag(rel.Tag, head.Hash(), nil)
+	return err
+}
+
+func (builder *ReleaseBuilder) ReadHead() (*object.Commit, error) {
+	ref, err := builder.Repository.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.Repository.CommitObject(ref.Hash())
+}
+
+func (builder *ReleaseBuilder) ReadMailmap() (Mailmap, error) {
+	head, err := builder.ReadHead()
+	if err != nil || head == nil {
+		return nil, err
+	}
+
+	tree, err := builder.Repository.TreeObject(head.TreeHash)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := tree.File(".mailmap")
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseMailmap(contents), nil
+}
+
 func (builder *ReleaseBuilder) ReadCurrentVersion() (*semver.Version, error) {
 	tags, err := builder.Repository.Tags()
 	if err != nil {
@@ -80,7 +282,7 @@ func (builder *ReleaseBuilder) ReadCurrentVersion() (*semver.Version, error) {
 
 	var vsn *semver.Version
 	err = tags.ForEach(func(ref *plumbing.Reference) error {
-		tagVsn, err := semver.NewVersion(ref.Name().Short())
+		tagVsn, err := builder.Config.ParseTag(ref.Name().Short())
 		if err != nil {
 			return nil
 		}
@@ -93,14 +295,197 @@ func (builder *ReleaseBuilder) ReadCurrentVersion() (*semver.Version, error) {
 		return nil
 	})
 
+	if err != nil || vsn == nil {
+		return nil, err
+	}
+
+	ref, err := builder.PeelReference(vsn.Reference())
 	if err != nil {
 		return nil, err
 	}
 
-	return vsn, nil
+	return vsn.WithReference(ref), nil
+}
+
+func (builder *ReleaseBuilder) PeelReference(ref *plumbing.Reference) (*plumbing.Reference, error) {
+	hash := ref.Hash()
+	for {
+		tag, err := builder.Repository.TagObject(hash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		hash = tag.Target
+	}
+
+	if hash == ref.Hash() {
+		return ref, nil
+	}
+
+	return plumbing.NewHashReference(ref.Name(), hash), nil
 }
 
 func (builder *ReleaseBuilder) NewReleaseVersion(version *semver.Version) *semver.Version {
-	vsn := &(*semver.SelectLatest(semver.NewEmptyVersion(), version))
+	vsn := semver.SelectLatest(semver.NewEmptyVersion(), version).Clone()
 	return vsn.WithPrefix(builder.Config.VersionPrefix)
 }
+
+func (builder *ReleaseBuilder) collapseDuplicates(changes []*change) ([]*change, error) {
+	duplicates := map[plumbing.Hash]bool{}
+	for _, change := range changes {
+		if change.CherryPick == "" {
+			continue
+		}
+
+		for _, other := range changes {
+			if other != change && strings.HasPrefix(other.Hash.String(), change.CherryPick) {
+				duplicates[change.Hash] = true
+				break
+			}
+		}
+	}
+
+	categories := map[string]int{}
+	for _, change := range changes {
+		if !duplicates[change.Hash] && change.NumParents() < 2 {
+			categories[change.spec.Category]++
+		}
+	}
+
+	patches := map[plumbing.Hash]bool{}
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		if duplicates[change.Hash] || change.NumParents() > 1 || categories[change.spec.Category] < 2 {
+			continue
+		}
+
+		id, err := injection.PatchID(builder.Repository, change.Commit)
+		if errors.Is(err, plumbing.ErrObjectNotFound) || id.IsZero() {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if patches[id] {
+			duplicates[change.Hash] = true
+		}
+
+		patches[id] = true
+	}
+
+	var result []*change
+	for _, change := range changes {
+		if !duplicates[change.Hash] {
+			result = append(result, change)
+		}
+	}
+
+	return result, nil
+}
+
+func (builder *ReleaseBuilder) collapseMerged(changes []*change, hashes []plumbing.Hash) ([]*change, error) {
+	inRange := map[plumbing.Hash]bool{}
+	for _, hash := range hashes {
+		inRange[hash] = true
+	}
+
+	duplicates := map[plumbing.Hash]bool{}
+	for _, merge := range changes {
+		if merge.NumParents() < 2 {
+			continue
+		}
+
+		merged, err := builder.readMerged(merge.Commit, inRange)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, other := range changes {
+			if merged[other.Hash] && mergeKey(other.ConventionalCommit) == mergeKey(merge.ConventionalCommit) {
+				duplicates[other.Hash] = true
+			}
+		}
+	}
+
+	var result []*change
+	for _, change := range changes {
+		if !duplicates[change.Hash] {
+			result = append(result, change)
+		}
+	}
+
+	return result, nil
+}
+
+func (builder *ReleaseBuilder) readMerged(merge *object.Commit, inRange map[plumbing.Hash]bool) (map[plumbing.Hash]bool, error) {
+	first, err := builder.Repository.CommitObject(merge.ParentHashes[0])
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[plumbing.Hash]bool{}
+	merged := map[plumbing.Hash]bool{}
+	queue := append([]plumbing.Hash{}, merge.ParentHashes[1:]...)
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+		if visited[hash] || !inRange[hash] {
+			continue
+		}
+
+		visited[hash] = true
+		commit, err := builder.Repository.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		base, err := commit.IsAncestor(first)
+		if err != nil {
+			return nil, err
+		}
+
+		if !base {
+			merged[hash] = true
+			queue = append(queue, commit.ParentHashes...)
+		}
+	}
+
+	return merged, nil
+}
+
+func mergeKey(cc *conventionalcommits.ConventionalCommit) string {
+	description := conventionalcommits.PullRequestRegex.ReplaceAllString(cc.Description, "")
+	return strings.ToLower(cc.Type + "(" + cc.Scope + "): " + strings.TrimSpace(description))
+}
+
+func cancelReverts(changes []*change, hashes []plumbing.Hash) []*change {
+	cancelled := map[plumbing.Hash]bool{}
+	for _, change := range changes {
+		if change.Reverts == "" || cancelled[change.Hash] {
+			continue
+		}
+
+		for _, hash := range hashes {
+			if strings.HasPrefix(hash.String(), change.Reverts) {
+				cancelled[hash] = true
+				cancelled[change.Hash] = true
+				break
+			}
+		}
+	}
+
+	var result []*change
+	for _, change := range changes {
+		if !cancelled[change.Hash] {
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
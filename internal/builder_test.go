@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/bajankristof/relgen/internal/mocking"
 	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"regexp"
@@ -141,3 +142,282 @@ func TestReleaseBuilder_BuildSinceLogError(t *testing.T) {
 		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected release to be <nil>, got %v", builder, rel, err, rel)
 	}
 }
+
+func TestReleaseBuilder_Describe(t *testing.T) {
+	head := plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4")
+	repo := &mocking.MockRepository{
+		HeadReturn: plumbing.NewHashReference("HEAD", head),
+		LogReturn: &mocking.MockCommitIter{Commits: []*object.Commit{
+			{Message: "feat: nice and conventional", Hash: head, ParentHashes: []plumbing.Hash{}},
+			{Message: "not so conventional", Hash: plumbing.NewHash("222"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fix: already released", Hash: plumbing.NewHash("333"), ParentHashes: []plumbing.Hash{}},
+		}},
+		TagsReturn: &mocking.MockReferenceIter{
+			References: []*plumbing.Reference{
+				plumbing.NewHashReference("1.1.0", plumbing.NewHash("333")),
+			},
+		},
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.Describe()
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).Describe() = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case rel.Version.String() != "1.2.0-dev.2+g3fa2b1c":
+		t.Fatalf("(*ReleaseBuilder(%v)).Describe() = (%v, %v), expected release version to be 1.2.0-dev.2+g3fa2b1c, got %v", builder, rel, err, rel.Version)
+	}
+
+	repo.LogReturn.Commits = repo.LogReturn.Commits[1:]
+	repo.LogReturn.Commits[0].Hash = plumbing.NewHash("8c1f0e2a3b4c5d6e7f8091a2b3c4d5e6f7081920")
+	repo.HeadReturn = plumbing.NewHashReference("HEAD", repo.LogReturn.Commits[0].Hash)
+	rel, err = builder.Describe()
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).Describe() = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case rel.Version.String() != "1.1.1-dev.1+g8c1f0e2":
+		t.Fatalf("(*ReleaseBuilder(%v)).Describe() = (%v, %v), expected release version to be 1.1.1-dev.1+g8c1f0e2, got %v", builder, rel, err, rel.Version)
+	}
+
+	builder.Config.BuildMetadata = "build.7"
+	rel, err = builder.Describe()
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).Describe() = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case rel.Version.String() != "1.1.1-dev.1+build.7":
+		t.Fatalf("(*ReleaseBuilder(%v)).Describe() = (%v, %v), expected release version to be 1.1.1-dev.1+build.7, got %v", builder, rel, err, rel.Version)
+	}
+}
+
+func TestReleaseBuilder_ReadCurrentVersionWithTagPattern(t *testing.T) {
+	repo := &mocking.MockRepository{
+		TagsReturn: &mocking.MockReferenceIter{
+			References: []*plumbing.Reference{
+				plumbing.NewReferenceFromStrings("release-1.2", "a"),
+				plumbing.NewReferenceFromStrings("Version_1.1.9", "b"),
+				plumbing.NewReferenceFromStrings("9.9.9", "c"),
+			},
+		},
+	}
+
+	pattern, _ := NewTagPattern("(?i)^(release-|version_)(?P<version>.+)$")
+	builder := NewReleaseBuilder(repo, &Config{TagPattern: pattern})
+	vsn, err := builder.ReadCurrentVersion()
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).ReadCurrentVersion() = (%v, %v), expected error to be <nil>, got %v", builder, vsn, err, err)
+	case vsn.String() != "1.2.0":
+		t.Fatalf("(*ReleaseBuilder(%v)).ReadCurrentVersion() = (%v, %v), expected version to be 1.2.0, got %v", builder, vsn, err, vsn)
+	case vsn.Reference() != repo.TagsReturn.References[0]:
+		t.Fatalf("(*ReleaseBuilder(%v)).ReadCurrentVersion() = (%v, %v), expected reference to be %v, got %v", builder, vsn, err, repo.TagsReturn.References[0], vsn.Reference())
+	}
+}
+
+func TestReleaseBuilder_BuildSinceMergeBody(t *testing.T) {
+	head := plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4")
+	repo := &mocking.MockRepository{
+		HeadReturn: plumbing.NewHashReference("HEAD", head),
+		LogReturn: &mocking.MockCommitIter{Commits: []*object.Commit{
+			{Message: "Merge pull request #12 from foo/bar\n\nfeat: conventional title", Hash: head, ParentHashes: []plumbing.Hash{plumbing.NewHash("222"), plumbing.NewHash("333")}},
+			{Message: "fix: direct commit", Hash: plumbing.NewHash("222"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fix: merged commit", Hash: plumbing.NewHash("333"), ParentHashes: []plumbing.Hash{plumbing.NewHash("222")}},
+		}},
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{CommitSource: CommitSourceMergeBody, ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.BuildSince(nil)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(rel.Changelog["Features"]) != 1 || rel.Changelog["Features"][0].PullRequest != 12:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected the merge commit to be a feature of pull request #12, got %v", builder, rel, err, rel.Changelog["Features"])
+	case len(rel.Changelog["Fixes"]) != 1 || rel.Changelog["Fixes"][0].Description != "direct commit":
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected only the direct commit to be a fix, got %v", builder, rel, err, rel.Changelog["Fixes"])
+	}
+}
+
+func TestReleaseBuilder_BuildSinceBoth(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	typo := mocking.CommitFiles(repo, "fix: typo", map[string]string{"a.txt": "a\n"})
+	direct := mocking.CommitFiles(repo, "fix: typo", map[string]string{"b.txt": "b\n"}, typo)
+	merged := mocking.CommitFiles(repo, "feat: conventional title", nil, typo)
+	mocking.CommitFiles(repo, "Merge pull request #12 from foo/bar\n\nfeat: conventional title", nil, direct, merged)
+
+	builder := NewReleaseBuilder(repo, &Config{CommitSource: CommitSourceBoth, ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.BuildSince(nil)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(rel.Changelog["Features"]) != 1 || rel.Changelog["Features"][0].PullRequest != 12:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected a single feature of pull request #12, got %v", builder, rel, err, rel.Changelog["Features"])
+	case len(rel.Changelog["Fixes"]) != 2:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected both distinct typo fixes, got %v", builder, rel, err, rel.Changelog["Fixes"])
+	}
+}
+
+func TestReleaseBuilder_BuildSinceReverts(t *testing.T) {
+	feat := plumbing.NewHash("1fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4")
+	repo := &mocking.MockRepository{
+		LogReturn: &mocking.MockCommitIter{Commits: []*object.Commit{
+			{Message: "revert: fix: released fix\n\nThis reverts commit 9e8d7c6b5a4.", Hash: plumbing.NewHash("4fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "Revert \"feat: unreleased feature\"\n\nThis reverts commit 1fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4.", Hash: plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fix: unreleased fix", Hash: plumbing.NewHash("2fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "feat: unreleased feature", Hash: feat, ParentHashes: []plumbing.Hash{}},
+		}},
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	vsn, _ := semver.NewVersion("1.0.0")
+	rel, err := builder.BuildSince(vsn)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(%v) = (%v, %v), expected error to be <nil>, got %v", builder, vsn, rel, err, err)
+	case len(rel.Changelog["Features"]) != 0:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(%v) = (%v, %v), expected the reverted feature to be removed, got %v", builder, vsn, rel, err, rel.Changelog["Features"])
+	case len(rel.Changelog["Fixes"]) != 1:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(%v) = (%v, %v), expected a single fix, got %v", builder, vsn, rel, err, rel.Changelog["Fixes"])
+	case len(rel.Changelog[RevertChangeSpec.Category]) != 1 || rel.Changelog[RevertChangeSpec.Category][0].Reverts != "9e8d7c6b5a4":
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(%v) = (%v, %v), expected the revert of the released fix to be listed, got %v", builder, vsn, rel, err, rel.Changelog[RevertChangeSpec.Category])
+	case rel.Version.String() != "1.0.1":
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(%v) = (%v, %v), expected release version to be 1.0.1, got %v", builder, vsn, rel, err, rel.Version)
+	}
+}
+
+func TestReleaseBuilder_BuildSinceDuplicates(t *testing.T) {
+	repo := &mocking.MockRepository{
+		LogReturn: &mocking.MockCommitIter{Commits: []*object.Commit{
+			{Message: "fix: backported\n\n(cherry picked from commit 1fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4)", Hash: plumbing.NewHash("2fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fix: backported", Hash: plumbing.NewHash("1fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+		}},
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.BuildSince(nil)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(rel.Changelog["Fixes"]) != 1 || rel.Changelog["Fixes"][0].Commit != repo.LogReturn.Commits[1]:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected only the original fix to be listed, got %v", builder, rel, err, rel.Changelog["Fixes"])
+	}
+
+	builder.Config.KeepDuplicates = true
+	rel, err = builder.BuildSince(nil)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(rel.Changelog["Fixes"]) != 2:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected both fixes to be listed, got %v", builder, rel, err, rel.Changelog["Fixes"])
+	}
+}
+
+func TestReleaseBuilder_BuildSincePatchDuplicates(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	root := mocking.CommitFiles(repo, "chore: init", map[string]string{"a.txt": "a\n"})
+	original := mocking.CommitFiles(repo, "fix: foo", map[string]string{"foo.txt": "foo\n"})
+	mocking.CommitFiles(repo, "fix: foo", map[string]string{"foo.txt": "bar\n"})
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree(), expected error to be <nil>, got %v", err)
+	}
+
+	if err = worktree.Checkout(&git.CheckoutOptions{Hash: root, Branch: "refs/heads/backport", Create: true}); err != nil {
+		t.Fatalf("Checkout(...), expected error to be <nil>, got %v", err)
+	}
+
+	mocking.CommitFiles(repo, "fix: foo (backport)", map[string]string{"foo.txt": "foo\n"})
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.BuildSince(nil)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(rel.Changelog["Fixes"]) != 2:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected 2 fixes to be listed, got %v", builder, rel, err, rel.Changelog["Fixes"])
+	}
+
+	for _, cc := range rel.Changelog["Fixes"] {
+		if cc.NumParents() == 1 && cc.ParentHashes[0] == root && cc.Hash != original {
+			t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected the backported fix to be collapsed into %v", builder, rel, err, original)
+		}
+	}
+}
+
+type treeErrorRepository struct {
+	*git.Repository
+	err error
+}
+
+func (repo *treeErrorRepository) TreeObject(plumbing.Hash) (*object.Tree, error) {
+	return nil, repo.err
+}
+
+func TestReleaseBuilder_BuildSincePatchErrors(t *testing.T) {
+	memory := mocking.NewMemoryRepository()
+	mocking.CommitFiles(memory, "fix: foo", map[string]string{"foo.txt": "foo\n"})
+	mocking.CommitFiles(memory, "fix: bar", map[string]string{"bar.txt": "bar\n"})
+	repo := &treeErrorRepository{Repository: memory, err: plumbing.ErrObjectNotFound}
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.BuildSince(nil)
+	if err != nil || len(rel.Changelog["Fixes"]) != 2 {
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected missing objects to be skipped", builder, rel, err)
+	}
+
+	repo.err = errors.New("corrupt tree")
+	rel, err = builder.BuildSince(nil)
+	if err != repo.err {
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be %v", builder, rel, err, repo.err)
+	}
+}
+
+func TestReleaseBuilder_BuildSinceContributors(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	mocking.CommitFiles(repo, "chore: init", map[string]string{".mailmap": "Jane D. <jane@corp.example.com> <jane@example.com>\n"})
+	mocking.CommitFiles(repo, "feat: foo\n\nCo-authored-by: John Smith <john@example.com>\nCo-authored-by: dependabot[bot] <support@github.com>", nil)
+	mocking.CommitFiles(repo, "fix: bar\n\nCo-authored-by: Jane <jane@corp.example.com>", nil)
+
+	builder := NewReleaseBuilder(repo, &Config{Contributors: true, ExcludeContributors: []string{"\\[bot\\]$"}, ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.BuildSince(nil)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(rel.Contributors) != 2:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected 2 contributors, got %v", builder, rel, err, rel.Contributors)
+	case rel.Contributors[0].Name != "Jane D." || rel.Contributors[0].Email != "jane@corp.example.com":
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected contributors[0] to be Jane D., got %v", builder, rel, err, rel.Contributors[0])
+	case rel.Contributors[1].Name != "John Smith":
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected contributors[1] to be John Smith, got %v", builder, rel, err, rel.Contributors[1])
+	}
+
+	builder.Config.Contributors = false
+	rel, _ = builder.BuildSince(nil)
+	if len(rel.Contributors) != 0 {
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected no contributors, got %v", builder, rel, err, rel.Contributors)
+	}
+}
+
+func TestReleaseBuilder_CreateTag(t *testing.T) {
+	head := plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4")
+	repo := &mocking.MockRepository{HeadReturn: plumbing.NewHashReference("HEAD", head)}
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	rel := &Release{Tag: "v1.2.0"}
+	err := builder.CreateTag(rel)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).CreateTag(%v) = %v, expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(repo.TagsReturn.References) != 1 || repo.TagsReturn.References[0].Name().Short() != "v1.2.0" || repo.TagsReturn.References[0].Hash() != head:
+		t.Fatalf("(*ReleaseBuilder(%v)).CreateTag(%v) = %v, expected tag v1.2.0 at %s, got %v", builder, rel, err, head, repo.TagsReturn.References)
+	}
+}
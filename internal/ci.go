@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const DefaultDotenvPath = "relgen.env"
+
+type CISpec struct {
+	Disabled bool   `json:"disabled"`
+	Dotenv   string `json:"dotenv"`
+}
+
+func CIOutputs(rel *Release, released bool) [][2]string {
+	var previous string
+	if rel.Statistics.PreviousVersion != nil {
+		previous = rel.Statistics.PreviousVersion.String()
+	}
+
+	return [][2]string{
+		{"version", rel.Version.String()},
+		{"tag", rel.Tag},
+		{"previous_version", previous},
+		{"bump", rel.Bump},
+		{"released", strconv.FormatBool(released)},
+		{"prerelease", strconv.FormatBool(rel.Version.IsPreRelease())},
+	}
+}
+
+func (spec *CISpec) Write(rel *Release, released bool) error {
+	if spec.Disabled {
+		return nil
+	}
+
+	outputs := CIOutputs(rel, released)
+	if path := os.Getenv("GITHUB_OUTPUT"); path != "" {
+		str := &strings.Builder{}
+		for _, output := range outputs {
+			fmt.Fprintf(str, "%s=%s\n", output[0], output[1])
+		}
+
+		if err := appendFile(path, str.String()); err != nil {
+			return err
+		}
+	}
+
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" && released {
+		str := &strings.Builder{}
+		if err := DefaultChangelogOutput.Template.Execute(str, rel); err != nil {
+			return err
+		}
+
+		if err := appendFile(path, str.String()+"\n"); err != nil {
+			return err
+		}
+	}
+
+	if os.Getenv("GITLAB_CI") == "true" {
+		path := spec.Dotenv
+		if path == "" {
+			path = DefaultDotenvPath
+		}
+
+		str := &strings.Builder{}
+		for _, output := range outputs {
+			fmt.Fprintf(str, "RELGEN_%s=%s\n", strings.ToUpper(output[0]), output[1])
+		}
+
+		if err := os.WriteFile(path, []byte(str.String()), 0666); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func appendFile(path string, str string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.WriteString(str); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
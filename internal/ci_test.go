@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestCISpec_Write(t *testing.T) {
+	dir := t.TempDir()
+	output, summary, dotenv := path.Join(dir, "output"), path.Join(dir, "summary"), path.Join(dir, "relgen.env")
+	t.Setenv("GITHUB_OUTPUT", output)
+	t.Setenv("GITHUB_STEP_SUMMARY", summary)
+	t.Setenv("GITLAB_CI", "true")
+	if err := os.WriteFile(output, []byte("other=1\n"), 0666); err != nil {
+		panic(err)
+	}
+
+	previous, _ := semver.NewVersion("1.1.0")
+	vsn, _ := semver.NewVersion("1.2.0-rc.1")
+	rel := NewRelease(vsn)
+	rel.Tag, rel.Bump = "v1.2.0-rc.1", semver.MINOR
+	rel.Statistics.PreviousVersion = previous
+	rel.Push(&conventionalcommits.ConventionalCommit{Commit: &object.Commit{}, Description: "foo"}, &ChangeSpec{Bump: semver.MINOR, Category: "Features"})
+
+	spec := &CISpec{Dotenv: dotenv}
+	err := spec.Write(rel, true)
+	outputData, _ := os.ReadFile(output)
+	summaryData, _ := os.ReadFile(summary)
+	dotenvData, _ := os.ReadFile(dotenv)
+
+	switch true {
+	case err != nil:
+		t.Fatalf(`(*CISpec(%v)).Write(...) = %v, expected error to be <nil>, got %v`, spec, err, err)
+	case string(outputData) != "other=1\nversion=1.2.0-rc.1\ntag=v1.2.0-rc.1\nprevious_version=1.1.0\nbump=MINOR\nreleased=true\nprerelease=true\n":
+		t.Fatalf(`(*CISpec(%v)).Write(...) = %v, expected the GitHub outputs to be appended, got %q`, spec, err, outputData)
+	case !strings.Contains(string(summaryData), "### Features\n* foo"):
+		t.Fatalf(`(*CISpec(%v)).Write(...) = %v, expected the changelog in the job summary, got %q`, spec, err, summaryData)
+	case !strings.Contains(string(dotenvData), "RELGEN_VERSION=1.2.0-rc.1\n") || !strings.Contains(string(dotenvData), "RELGEN_RELEASED=true\n"):
+		t.Fatalf(`(*CISpec(%v)).Write(...) = %v, expected the dotenv file to be written, got %q`, spec, err, dotenvData)
+	}
+
+	_ = os.Remove(summary)
+	_ = spec.Write(rel, false)
+	if _, err = os.Stat(summary); !os.IsNotExist(err) {
+		t.Fatalf(`(*CISpec(%v)).Write(...), expected no job summary without a release`, spec)
+	}
+}
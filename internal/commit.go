@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultCommitMessage = "chore(release): {{.Version}}"
+	ReleaseFooter        = "Relgen-Release"
+)
+
+type CommitSpec struct {
+	Message string        `json:"message"`
+	Author  *CommitAuthor `json:"author"`
+}
+
+type CommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func (spec *CommitSpec) RenderMessage(rel *Release) (string, error) {
+	message := spec.Message
+	if message == "" {
+		message = DefaultCommitMessage
+	}
+
+	str, err := RenderString(message, rel)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(str) + "\n\n" + ReleaseFooter + ": " + rel.Version.String() + "\n", nil
+}
+
+func (group OutputWriterGroup) Paths() []string {
+	var paths []string
+	for _, writer := range group {
+		paths = append(paths, writer.Path)
+	}
+
+	return paths
+}
+
+func (builder *ReleaseBuilder) CommitOutputs(rel *Release, paths []string) (plumbing.Hash, error) {
+	message, err := builder.Config.Commit.RenderMessage(rel)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	worktree, err := builder.Repository.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	root := worktree.Filesystem.Root()
+	outputs := map[string]bool{}
+	for _, path := range paths {
+		if filepath.IsAbs(path) {
+			if path, err = filepath.Rel(root, path); err != nil {
+				return plumbing.ZeroHash, err
+			}
+		}
+
+		outputs[filepath.ToSlash(filepath.Clean(path))] = true
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	var staged []string
+	for name, file := range status {
+		if file.Staging != git.Unmodified && file.Staging != git.Untracked && !outputs[name] {
+			staged = append(staged, name)
+		}
+	}
+
+	if len(staged) > 0 {
+		sort.Strings(staged)
+		return plumbing.ZeroHash, fmt.Errorf("refusing to create the release commit, files other than the outputs are staged: %s", strings.Join(staged, ", "))
+	}
+
+	for path := range outputs {
+		if _, err = worktree.Add(path); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	options := &git.CommitOptions{}
+	if author := builder.Config.Commit.Author; author != nil {
+		options.Author = &object.Signature{Name: author.Name, Email: author.Email, When: time.Now()}
+	}
+
+	return worktree.Commit(message, options)
+}
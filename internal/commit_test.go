@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/bajankristof/relgen/internal/semver"
+	"testing"
+)
+
+func TestReleaseBuilder_CommitOutputs(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	mocking.CommitFiles(repo, "fix: foo", map[string]string{"main.go": "package main\n"})
+	worktree, _ := repo.Worktree()
+	for _, name := range []string{"version.txt", "untracked.txt"} {
+		file, _ := worktree.Filesystem.Create(name)
+		_, _ = file.Write([]byte("1.0.1"))
+		_ = file.Close()
+	}
+
+	cfg := &Config{
+		Commit:     CommitSpec{Message: "feat: release {{.Version}}", Author: &CommitAuthor{Name: "Release Bot", Email: "bot@example.com"}},
+		ChangeSpec: DefaultChangeSpec,
+	}
+
+	builder := NewReleaseBuilder(repo, cfg)
+	vsn, _ := semver.NewVersion("1.0.1")
+	rel := NewRelease(vsn)
+	hash, err := builder.CommitOutputs(rel, []string{"./version.txt"})
+	if err != nil {
+		t.Fatalf("(*ReleaseBuilder(%v)).CommitOutputs(...) = (%v, %v), expected error to be <nil>, got %v", builder, hash, err, err)
+	}
+
+	commit, _ := repo.CommitObject(hash)
+	tree, _ := commit.Tree()
+	_, versionErr := tree.File("version.txt")
+	_, untrackedErr := tree.File("untracked.txt")
+
+	switch true {
+	case commit.Message != "feat: release 1.0.1\n\nRelgen-Release: 1.0.1\n":
+		t.Fatalf("(*ReleaseBuilder(%v)).CommitOutputs(...) = (%v, %v), expected the rendered message, got %q", builder, hash, err, commit.Message)
+	case commit.Author.Name != "Release Bot" || commit.Author.Email != "bot@example.com":
+		t.Fatalf("(*ReleaseBuilder(%v)).CommitOutputs(...) = (%v, %v), expected the configured author, got %v", builder, hash, err, commit.Author)
+	case versionErr != nil || untrackedErr == nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).CommitOutputs(...) = (%v, %v), expected to only commit version.txt", builder, hash, err)
+	}
+
+	rel, err = builder.BuildSince(nil)
+	if err != nil || len(rel.Changelog["Features"]) != 0 || len(rel.Changelog["Fixes"]) != 1 {
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected the release commit to be ignored, got %v", builder, rel, err, rel.Changelog)
+	}
+}
+
+func TestReleaseBuilder_CommitOutputsWithStagedFiles(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	mocking.CommitFiles(repo, "fix: foo", map[string]string{"main.go": "package main\n"})
+	worktree, _ := repo.Worktree()
+	for _, name := range []string{"version.txt", "staged.txt"} {
+		file, _ := worktree.Filesystem.Create(name)
+		_, _ = file.Write([]byte("1.0.1"))
+		_ = file.Close()
+	}
+
+	if _, err := worktree.Add("staged.txt"); err != nil {
+		t.Fatalf("Add(staged.txt), expected error to be <nil>, got %v", err)
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	head, _ := repo.Head()
+	vsn, _ := semver.NewVersion("1.0.1")
+	hash, err := builder.CommitOutputs(NewRelease(vsn), []string{"version.txt"})
+	after, _ := repo.Head()
+
+	switch true {
+	case err == nil || err.Error() != "refusing to create the release commit, files other than the outputs are staged: staged.txt":
+		t.Fatalf("(*ReleaseBuilder(%v)).CommitOutputs(...) = (%v, %v), expected a staged files error", builder, hash, err)
+	case after.Hash() != head.Hash():
+		t.Fatalf("(*ReleaseBuilder(%v)).CommitOutputs(...) = (%v, %v), expected no commit to be created", builder, hash, err)
+	}
+}
@@ -6,8 +6,19 @@ import (
 	"fmt"
 	"github.com/bajankristof/relgen/internal/conventionalcommits"
 	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/bajankristof/relgen/internal/utils"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	CommitSourceCommits      = "commits"
+	CommitSourceMergeSubject = "merge-subject"
+	CommitSourceMergeBody    = "merge-body"
+	CommitSourceBoth         = "both"
 )
 
 var DefaultChangeSpec = []ChangeSpec{
@@ -16,12 +27,49 @@ var DefaultChangeSpec = []ChangeSpec{
 	{&TypeSpec{regexp.MustCompile("^build|chore|ci|docs|style|refactor|perf|test$")}, semver.PATCH, "Other"},
 }
 
+var KnownTypes = map[string]string{
+	"feat":     "feat",
+	"feature":  "feat",
+	"fix":      "fix",
+	"bugfix":   "fix",
+	"build":    "build",
+	"chore":    "chore",
+	"ci":       "ci",
+	"docs":     "docs",
+	"style":    "style",
+	"refactor": "refactor",
+	"perf":     "perf",
+	"test":     "test",
+	"revert":   "revert",
+}
+
+var RevertChangeSpec = ChangeSpec{&TypeSpec{regexp.MustCompile("(?i)^revert$")}, semver.PATCH, "Reverts"}
+
 type Config struct {
-	PreRelease    string            `json:"preRelease"`
-	BuildMetadata string            `json:"buildMetadata"`
-	VersionPrefix bool              `json:"versionPrefix"`
-	ChangeSpec    []ChangeSpec      `json:"changeSpec"`
-	Outputs       OutputWriterGroup `json:"outputs"`
+	PreRelease          string                            `json:"preRelease"`
+	SanitizePreRelease  bool                              `json:"sanitizePreRelease"`
+	BuildMetadata       string                            `json:"buildMetadata"`
+	VersionPrefix       bool                              `json:"versionPrefix"`
+	TagPattern          *TagPattern                       `json:"tagPattern"`
+	TagFormat           *TagTemplate                      `json:"tagFormat"`
+	HeaderParser        *conventionalcommits.HeaderParser `json:"headerParser"`
+	TypeAliases         map[string]string                 `json:"typeAliases"`
+	CommitSource        string                            `json:"commitSource"`
+	KeepDuplicates      bool                              `json:"keepDuplicates"`
+	Ignore              IgnoreSpec                        `json:"ignore"`
+	AllowedScopes       []string                          `json:"allowedScopes"`
+	Contributors        bool                              `json:"contributors"`
+	ExcludeContributors []string                          `json:"excludeContributors"`
+	IssuePatterns       IssuePatternGroup                 `json:"issuePatterns"`
+	ChangeSpec          []ChangeSpec                      `json:"changeSpec"`
+	Outputs             OutputWriterGroup                 `json:"outputs"`
+	Publishers          PublisherGroup                    `json:"publishers"`
+	Webhooks            WebhookGroup                      `json:"webhooks"`
+	Hooks               HookSpec                          `json:"hooks"`
+	Commit              CommitSpec                        `json:"commit"`
+	Push                PushSpec                          `json:"push"`
+	PullRequest         PullRequestSpec                   `json:"pullRequest"`
+	CI                  CISpec                            `json:"ci"`
 }
 
 type ChangeSpec struct {
@@ -38,7 +86,7 @@ func ReadConfig(path string) (*Config, error) {
 	_, err := os.Stat(path)
 	if errors.Is(err, os.ErrNotExist) {
 		cfg := &Config{ChangeSpec: DefaultChangeSpec, Outputs: DefaultOutputGroup}
-		return cfg, nil
+		return cfg, cfg.Ignore.ReadFile(filepath.Dir(path))
 	}
 
 	bytes, err := os.ReadFile(path)
@@ -52,6 +100,11 @@ func ReadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
+	err = cfg.Ignore.ReadFile(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
 	err = cfg.Check()
 	if err != nil {
 		return nil, err
@@ -61,6 +114,50 @@ func ReadConfig(path string) (*Config, error) {
 }
 
 func (cfg *Config) Check() error {
+	if cfg.SanitizePreRelease {
+		cfg.PreRelease = semver.SanitizeIdentifier(cfg.PreRelease)
+	}
+
+	if err := semver.CheckPreReleaseTag(cfg.PreRelease); err != nil {
+		return err
+	}
+
+	if !strings.Contains(cfg.BuildMetadata, "{{") {
+		if err := semver.CheckMetadata(cfg.BuildMetadata); err != nil {
+			return err
+		}
+	}
+
+	switch cfg.CommitSource {
+	case "":
+		cfg.CommitSource = CommitSourceCommits
+	case
+		CommitSourceCommits,
+		CommitSourceMergeSubject,
+		CommitSourceMergeBody,
+		CommitSourceBoth:
+	default:
+		return fmt.Errorf("unrecognized commit source \"%s\"", cfg.CommitSource)
+	}
+
+	if err := cfg.checkTag(); err != nil {
+		return err
+	}
+
+	if err := cfg.Ignore.Check(); err != nil {
+		return err
+	}
+
+	for _, pattern := range cfg.ExcludeContributors {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.Publishers.Check(); err != nil {
+		return err
+	}
+
 	if len(cfg.Outputs) < 1 {
 		cfg.Outputs = DefaultOutputGroup
 	}
@@ -89,6 +186,157 @@ func (cfg *Config) FindChangeSpec(cc *conventionalcommits.ConventionalCommit) (i
 	return 0, nil
 }
 
+func (cfg *Config) Parser() *conventionalcommits.HeaderParser {
+	if cfg.HeaderParser == nil {
+		return conventionalcommits.ConventionalParser
+	}
+
+	return cfg.HeaderParser
+}
+
+func (cfg *Config) NormalizeType(kind string) string {
+	kind = strings.ToLower(kind)
+	if target, ok := cfg.TypeAliases[kind]; ok {
+		return strings.ToLower(target)
+	}
+
+	for _, alias := range cfg.aliases() {
+		if strings.EqualFold(alias, kind) {
+			return strings.ToLower(cfg.TypeAliases[alias])
+		}
+	}
+
+	return kind
+}
+
+func (cfg *Config) aliases() []string {
+	aliases := make([]string, 0, len(cfg.TypeAliases))
+	for alias := range cfg.TypeAliases {
+		aliases = append(aliases, alias)
+	}
+
+	sort.Strings(aliases)
+	return aliases
+}
+
+func (cfg *Config) SuggestType(kind string) string {
+	if _, spec := cfg.FindChangeSpec(&conventionalcommits.ConventionalCommit{Type: kind}); spec != nil {
+		return ""
+	}
+
+	candidates := map[string]string{}
+	for name, target := range KnownTypes {
+		candidates[name] = target
+	}
+
+	aliases := cfg.aliases()
+	for i := len(aliases) - 1; i >= 0; i-- {
+		target := cfg.TypeAliases[aliases[i]]
+		candidates[strings.ToLower(aliases[i])] = target
+		candidates[strings.ToLower(target)] = target
+	}
+
+	suggestion, distance := "", 3
+	for name, target := range candidates {
+		target = cfg.NormalizeType(target)
+		if _, spec := cfg.FindChangeSpec(&conventionalcommits.ConventionalCommit{Type: target}); spec == nil {
+			continue
+		}
+
+		if d := utils.EditDistance(kind, name); d < distance || (d == distance && target < suggestion) {
+			suggestion, distance = target, d
+		}
+	}
+
+	return suggestion
+}
+
+func (cfg *Config) FindIssues(cc *conventionalcommits.ConventionalCommit) []*conventionalcommits.Issue {
+	if len(cfg.IssuePatterns) < 1 {
+		return DefaultIssuePatterns.FindIssues(cc)
+	}
+
+	return cfg.IssuePatterns.FindIssues(cc)
+}
+
+func (cfg *Config) ExcludesContributor(contributor *Contributor) bool {
+	for _, pattern := range cfg.ExcludeContributors {
+		for _, str := range []string{contributor.Name, contributor.Email} {
+			if matched, _ := regexp.MatchString(pattern, str); matched || strings.EqualFold(pattern, str) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (cfg *Config) AllowsScopes(cc *conventionalcommits.ConventionalCommit) bool {
+	return cc.IsBreakingChange() || cfg.DisallowedScope(cc) == ""
+}
+
+func (cfg *Config) DisallowedScope(cc *conventionalcommits.ConventionalCommit) string {
+	if len(cfg.AllowedScopes) < 1 {
+		return ""
+	}
+
+	for _, scope := range cc.Scopes {
+		allowed := false
+		for _, allowedScope := range cfg.AllowedScopes {
+			allowed = allowed || strings.EqualFold(scope, allowedScope)
+		}
+
+		if !allowed {
+			return scope
+		}
+	}
+
+	return ""
+}
+
+func (cfg *Config) ParseTag(name string) (*semver.Version, error) {
+	if cfg.TagPattern == nil {
+		return semver.NewVersion(name)
+	}
+
+	return cfg.TagPattern.Parse(name)
+}
+
+func (cfg *Config) FormatTag(version *semver.Version) (string, error) {
+	switch true {
+	case cfg.TagFormat != nil:
+		return cfg.TagFormat.Format(version)
+	case cfg.TagPattern != nil && cfg.TagPattern.template != nil:
+		return (&TagTemplate{cfg.TagPattern.template}).Format(version)
+	default:
+		return version.String(), nil
+	}
+}
+
+func (cfg *Config) checkTag() error {
+	if cfg.TagPattern == nil && cfg.TagFormat == nil {
+		return nil
+	}
+
+	if cfg.TagPattern != nil && cfg.TagPattern.template == nil && cfg.TagFormat == nil {
+		return errors.New("tagFormat is required when tagPattern is a regular expression")
+	}
+
+	version, _ := semver.NewVersion("1.2.3-rc.1")
+	version.WithPrefix(cfg.VersionPrefix)
+	tag, err := cfg.FormatTag(version)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := cfg.ParseTag(tag)
+	if err != nil || parsed.WithPrefix(false).String() != version.WithPrefix(false).String() {
+		return fmt.Errorf("tags formatted with tagFormat must match tagPattern (e.g.: %s)", tag)
+	}
+
+	return nil
+}
+
 func (spec *ChangeSpec) Check() error {
 	switch spec.Bump {
 	case
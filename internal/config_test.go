@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
 	"github.com/bajankristof/relgen/internal/semver"
 	"os"
 	"path"
@@ -158,3 +159,142 @@ func TestTypeSpec_UnmarshalJSON(t *testing.T) {
 		t.Fatalf(`(*TypeSpec(%v)).UnmarshalJSON("\"[\""), expected error NOT to be <nil>`, spec)
 	}
 }
+
+func TestConfig_CheckPreRelease(t *testing.T) {
+	cfg := &Config{PreRelease: "feature/foo bar"}
+	if err := cfg.Check(); err == nil {
+		t.Fatalf(`(*Config(%v)).Check(), expected error NOT to be <nil>`, cfg)
+	}
+
+	cfg = &Config{PreRelease: "feature/foo bar", SanitizePreRelease: true}
+	err := cfg.Check()
+	switch true {
+	case err != nil:
+		t.Fatalf(`(*Config(%v)).Check(), expected error to be <nil>, got %v`, cfg, err)
+	case cfg.PreRelease != "feature-foo-bar":
+		t.Fatalf(`(*Config(%v)).Check(), expected pre-release to be "feature-foo-bar", got "%s"`, cfg, cfg.PreRelease)
+	}
+}
+
+func TestConfig_CheckBuildMetadata(t *testing.T) {
+	cfg := &Config{BuildMetadata: "foo bar"}
+	if err := cfg.Check(); err == nil {
+		t.Fatalf(`(*Config(%v)).Check(), expected error NOT to be <nil>`, cfg)
+	}
+
+	cfg = &Config{BuildMetadata: "{{.ShortHash}}"}
+	if err := cfg.Check(); err != nil {
+		t.Fatalf(`(*Config(%v)).Check(), expected error to be <nil>, got %v`, cfg, err)
+	}
+}
+
+func TestConfig_CheckCommitSource(t *testing.T) {
+	cfg := &Config{}
+	err := cfg.Check()
+	switch true {
+	case err != nil:
+		t.Fatalf(`(*Config(%v)).Check(), expected error to be <nil>, got %v`, cfg, err)
+	case cfg.CommitSource != CommitSourceCommits:
+		t.Fatalf(`(*Config(%v)).Check(), expected commit source to be "%s", got "%s"`, cfg, CommitSourceCommits, cfg.CommitSource)
+	}
+
+	cfg = &Config{CommitSource: "squash"}
+	if err := cfg.Check(); err == nil {
+		t.Fatalf(`(*Config(%v)).Check(), expected error NOT to be <nil>`, cfg)
+	}
+}
+
+func TestConfig_CheckTag(t *testing.T) {
+	regex, _ := NewTagPattern("(?i)^version_(?P<version>.+)$")
+	tmpl, _ := NewTagPattern("release-{{.Version}}")
+	format := &TagTemplate{}
+	_ = format.UnmarshalJSON([]byte(`"version_{{.Version}}"`))
+	mismatch := &TagTemplate{}
+	_ = mismatch.UnmarshalJSON([]byte(`"rel-{{.Version}}"`))
+
+	valid := []*Config{
+		{},
+		{TagPattern: tmpl},
+		{TagPattern: tmpl, VersionPrefix: true},
+		{TagPattern: regex, TagFormat: format},
+	}
+
+	for _, cfg := range valid {
+		if err := cfg.Check(); err != nil {
+			t.Fatalf(`(*Config(%v)).Check(), expected error to be <nil>, got %v`, cfg, err)
+		}
+	}
+
+	invalid := []*Config{
+		{TagPattern: regex},
+		{TagPattern: regex, TagFormat: mismatch},
+		{TagFormat: mismatch},
+	}
+
+	for _, cfg := range invalid {
+		if err := cfg.Check(); err == nil {
+			t.Fatalf(`(*Config(%v)).Check(), expected error NOT to be <nil>`, cfg)
+		}
+	}
+}
+
+func TestConfig_AllowsScopes(t *testing.T) {
+	cfg := &Config{}
+	cc := &conventionalcommits.ConventionalCommit{Scopes: []string{"core", "docs"}}
+	if !cfg.AllowsScopes(cc) {
+		t.Fatalf(`(*Config(%v)).AllowsScopes(%v), expected true, got false`, cfg, cc)
+	}
+
+	cfg = &Config{AllowedScopes: []string{"Core", "cli"}}
+	if cfg.AllowsScopes(cc) {
+		t.Fatalf(`(*Config(%v)).AllowsScopes(%v), expected false, got true`, cfg, cc)
+	}
+
+	cc = &conventionalcommits.ConventionalCommit{Scopes: []string{"core", "CLI"}}
+	if !cfg.AllowsScopes(cc) {
+		t.Fatalf(`(*Config(%v)).AllowsScopes(%v), expected true, got false`, cfg, cc)
+	}
+
+	cc = &conventionalcommits.ConventionalCommit{}
+	if !cfg.AllowsScopes(cc) {
+		t.Fatalf(`(*Config(%v)).AllowsScopes(%v), expected unscoped commits to be allowed, got false`, cfg, cc)
+	}
+
+	cc = &conventionalcommits.ConventionalCommit{Scopes: []string{"docs"}, Exclamation: true}
+	if !cfg.AllowsScopes(cc) || cfg.DisallowedScope(cc) != "docs" {
+		t.Fatalf(`(*Config(%v)).AllowsScopes(%v), expected breaking changes to be allowed, got false`, cfg, cc)
+	}
+}
+
+func TestConfig_NormalizeType(t *testing.T) {
+	cfg := &Config{TypeAliases: map[string]string{"Feature": "feat", "bugfix": "FIX"}}
+	tests := map[string]string{"feature": "feat", "BugFix": "fix", "Feat": "feat", "docs": "docs"}
+	for kind, expect := range tests {
+		if got := cfg.NormalizeType(kind); got != expect {
+			t.Fatalf(`(*Config(%v)).NormalizeType("%s"), expected "%s", got "%s"`, cfg, kind, expect, got)
+		}
+	}
+
+	cfg = &Config{TypeAliases: map[string]string{"Feature": "fix", "FEATURE": "feat", "feature": "chore"}}
+	tests = map[string]string{"feature": "chore", "FeAtUrE": "chore"}
+	for i := 0; i < 20; i++ {
+		for kind, expect := range tests {
+			if got := cfg.NormalizeType(kind); got != expect {
+				t.Fatalf(`(*Config(%v)).NormalizeType("%s"), expected "%s", got "%s"`, cfg, kind, expect, got)
+			}
+		}
+
+		delete(cfg.TypeAliases, "feature")
+		tests = map[string]string{"feature": "feat", "FeAtUrE": "feat"}
+	}
+}
+
+func TestConfig_SuggestType(t *testing.T) {
+	cfg := &Config{ChangeSpec: DefaultChangeSpec}
+	tests := map[string]string{"fature": "feat", "fxi": "fix", "dcos": "docs", "feat": "", "whatever": ""}
+	for kind, expect := range tests {
+		if got := cfg.SuggestType(kind); got != expect {
+			t.Fatalf(`(*Config(%v)).SuggestType("%s"), expected "%s", got "%s"`, cfg, kind, expect, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"regexp"
+	"strings"
+)
+
+var ContributorRegex = regexp.MustCompile("^\\s*(?P<name>[^<]*?)\\s*<(?P<email>[^<>]*)>\\s*$")
+
+var mailmapRegex = regexp.MustCompile("^\\s*([^<#]*?)\\s*<([^<>]*)>(\\s*([^<#]*?)\\s*<([^<>]*)>)?")
+
+type Contributor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type Mailmap []*mailmapEntry
+
+type mailmapEntry struct {
+	name        string
+	email       string
+	commitName  string
+	commitEmail string
+}
+
+func ParseContributor(str string) *Contributor {
+	match := ContributorRegex.FindStringSubmatch(str)
+	if match == nil {
+		return nil
+	}
+
+	return &Contributor{Name: match[1], Email: match[2]}
+}
+
+func FindContributors(cc *conventionalcommits.ConventionalCommit) []*Contributor {
+	contributors := []*Contributor{{Name: cc.Author.Name, Email: cc.Author.Email}}
+	for _, value := range cc.Footers["co-authored-by"] {
+		if contributor := ParseContributor(value); contributor != nil {
+			contributors = append(contributors, contributor)
+		}
+	}
+
+	return contributors
+}
+
+func ParseMailmap(str string) Mailmap {
+	var mailmap Mailmap
+	for _, line := range strings.Split(str, "\n") {
+		match := mailmapRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		entry := &mailmapEntry{name: match[1], email: match[2], commitEmail: match[2]}
+		if match[3] != "" {
+			entry.commitName, entry.commitEmail = match[4], match[5]
+		} else {
+			entry.email = ""
+		}
+
+		mailmap = append(mailmap, entry)
+	}
+
+	return mailmap
+}
+
+func (mailmap Mailmap) Resolve(contributor *Contributor) *Contributor {
+	var found *mailmapEntry
+	for _, entry := range mailmap {
+		if !strings.EqualFold(entry.commitEmail, contributor.Email) {
+			continue
+		}
+
+		if entry.commitName == "" && found == nil {
+			found = entry
+		}
+
+		if entry.commitName != "" && strings.EqualFold(entry.commitName, contributor.Name) {
+			found = entry
+			break
+		}
+	}
+
+	if found == nil {
+		return contributor
+	}
+
+	resolved := &Contributor{Name: contributor.Name, Email: contributor.Email}
+	if found.name != "" {
+		resolved.Name = found.name
+	}
+
+	if found.email != "" {
+		resolved.Email = found.email
+	}
+
+	return resolved
+}
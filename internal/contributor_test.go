@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestMailmap_Resolve(t *testing.T) {
+	mailmap := ParseMailmap(`# comment
+Jane Doe <jane@example.com>
+<john@example.com> <john@old.example.com>
+John Smith <john@example.com> jsmith <john@old.example.com>
+`)
+
+	tests := map[Contributor]Contributor{
+		{Name: "jane", Email: "JANE@example.com"}:       {Name: "Jane Doe", Email: "JANE@example.com"},
+		{Name: "john", Email: "john@old.example.com"}:   {Name: "john", Email: "john@example.com"},
+		{Name: "jsmith", Email: "john@old.example.com"}: {Name: "John Smith", Email: "john@example.com"},
+		{Name: "other", Email: "other@example.com"}:     {Name: "other", Email: "other@example.com"},
+	}
+
+	for contributor, expect := range tests {
+		got := mailmap.Resolve(&contributor)
+		if *got != expect {
+			t.Fatalf(`mailmap.Resolve(%v) = %v, expected %v`, contributor, got, expect)
+		}
+	}
+}
+
+func TestParseContributor(t *testing.T) {
+	contributor := ParseContributor("John Smith <john@example.com>")
+	switch true {
+	case contributor == nil:
+		t.Fatalf(`ParseContributor("John Smith <john@example.com>") = %v, expected NOT to be <nil>`, contributor)
+	case contributor.Name != "John Smith" || contributor.Email != "john@example.com":
+		t.Fatalf(`ParseContributor("John Smith <john@example.com>") = %v, expected John Smith <john@example.com>`, contributor)
+	}
+
+	if contributor = ParseContributor("John Smith"); contributor != nil {
+		t.Fatalf(`ParseContributor("John Smith") = %v, expected to be <nil>`, contributor)
+	}
+}
@@ -5,41 +5,89 @@ import (
 	"github.com/bajankristof/relgen/internal/utils"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 type ConventionalCommit struct {
 	*object.Commit
-	Type        string            `json:"type"`
-	Scope       string            `json:"scope"`
-	Exclamation bool              `json:"exclamation"`
-	Description string            `json:"description"`
-	Footers     map[string]string `json:"footers"`
-	Body        string            `json:"body"`
+	Type        string              `json:"type"`
+	Scope       string              `json:"scope"`
+	Scopes      []string            `json:"scopes"`
+	Exclamation bool                `json:"exclamation"`
+	Description string              `json:"description"`
+	Ticket      string              `json:"ticket"`
+	Footers     map[string][]string `json:"footers"`
+	Body        string              `json:"body"`
+	PullRequest int                 `json:"pullRequest"`
+	Reverts     string              `json:"reverts"`
+	CherryPick  string              `json:"cherryPick"`
+	Issues      []*Issue            `json:"issues"`
 }
 
+type Issue struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+var IssueFooters = []string{"closes", "close", "closed", "fixes", "fix", "fixed", "resolves", "resolve", "resolved", "refs", "ref", "references", "see"}
+
 var (
-	MessageRegex = regexp.MustCompile("(?i)^(?P<type>[a-z]{2,})(\\((?P<scope>[a-z]+)\\))?(?P<exclamation>!)?: (?P<description>[^ ].*)$")
-	FooterRegex  = regexp.MustCompile("(?i)^(?P<key>([a-z]+(-+[a-z]+)*|BREAKING[ -]CHANGE))((: | #)(?P<value>[^ ].*))?$")
+	MessageRegex     = regexp.MustCompile("(?i)^(?P<type>[a-z]{2,})(\\((?P<scope>[\\w./-]+(\\s*,\\s*[\\w./-]+)*)\\))?(?P<exclamation>!)?: (?P<description>[^ ].*)$")
+	MergeRegex       = regexp.MustCompile("^Merge (pull request #(?P<number>[0-9]+) from |(remote-tracking )?branch )")
+	RevertRegex      = regexp.MustCompile("^Revert \"(?P<description>.+)\"$")
+	RevertsRegex     = regexp.MustCompile("(?m)^This reverts commit (?P<hash>[0-9a-f]{7,40})")
+	CherryPickRegex  = regexp.MustCompile("^\\(cherry picked from commit (?P<hash>[0-9a-f]{7,40})\\)$")
+	PullRequestRegex = regexp.MustCompile("\\(#(?P<number>[0-9]+)\\)$")
+	ReferenceRegex   = regexp.MustCompile("\\((?P<reference>[^()\\s]+)\\)$")
+	FooterRegex      = regexp.MustCompile("(?i)^(?P<key>([a-z]+(-+[a-z]+)*|BREAKING[ -]CHANGE))(: | #|:$)(?P<value>.*)$")
 )
 
 func NewConventionalCommit(commit *object.Commit) (*ConventionalCommit, error) {
-	cc := &ConventionalCommit{Commit: commit, Footers: map[string]string{}}
-	chunks := strings.Split(strings.TrimRight(commit.Message, "\n"), "\n")
-	if !cc.parseMessage(chunks[0]) {
+	return ConventionalParser.NewConventionalCommit(commit)
+}
+
+func NewMergeConventionalCommit(commit *object.Commit, body bool) (*ConventionalCommit, error) {
+	return ConventionalParser.NewMergeConventionalCommit(commit, body)
+}
+
+func (parser *HeaderParser) newConventionalCommit(commit *object.Commit, chunks []string) (*ConventionalCommit, error) {
+	cc := &ConventionalCommit{Commit: commit, Footers: map[string][]string{}}
+	if !cc.parseHeader(parser, chunks[0]) && !cc.parseRevert(chunks[0]) {
 		return nil, errors.New("commit is not conventional")
 	}
 
-	cc.parseBodyAndFooters(chunks[1:])
+	cc.parsePullRequest()
+	cc.parseBodyAndFooters(cc.parseCherryPick(chunks[1:]))
+	if cc.IsRevert() {
+		cc.parseReverts(strings.Join(chunks[1:], "\n"))
+	}
+
 	return cc, nil
 }
 
+func NewNonConventionalCommit(commit *object.Commit) *ConventionalCommit {
+	chunks := strings.Split(strings.TrimRight(commit.Message, "\n"), "\n")
+	cc := &ConventionalCommit{Commit: commit, Description: chunks[0], Footers: map[string][]string{}}
+	cc.parseBodyAndFooters(cc.parseCherryPick(chunks[1:]))
+	return cc
+}
+
 func (cc *ConventionalCommit) AddFooter(key string, value string) {
 	if key != "BREAKING CHANGE" && key != "BREAKING-CHANGE" {
 		key = strings.ToLower(key)
 	}
 
-	cc.Footers[key] = value
+	cc.Footers[key] = append(cc.Footers[key], value)
+}
+
+func (cc *ConventionalCommit) Footer(key string) string {
+	if values := cc.Footers[key]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
 }
 
 func (cc *ConventionalCommit) HasFooter(key string) bool {
@@ -56,40 +104,178 @@ func (cc *ConventionalCommit) IsBreakingChange() bool {
 		cc.HasFooter("BREAKING-CHANGE")
 }
 
-func (cc *ConventionalCommit) parseMessage(message string) bool {
-	iter := &utils.NamedRegexpGroupIter{Regexp: MessageRegex}
-	return iter.ForEach(message, func(group string, match string) {
+func (cc *ConventionalCommit) References() []string {
+	var references []string
+	for _, key := range IssueFooters {
+		for _, value := range cc.Footers[key] {
+			for _, reference := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				if unicode.IsDigit([]rune(reference)[0]) {
+					reference = "#" + reference
+				}
+
+				references = append(references, reference)
+			}
+		}
+	}
+
+	iter := &utils.NamedRegexpGroupIter{Regexp: ReferenceRegex}
+	iter.ForEach(cc.Description, func(group string, match string) {
+		references = append(references, match)
+	})
+
+	return references
+}
+
+func (cc *ConventionalCommit) IsRevert() bool {
+	return strings.ToLower(cc.Type) == "revert"
+}
+
+func (cc *ConventionalCommit) parseHeader(parser *HeaderParser, header string) bool {
+	iter := &utils.NamedRegexpGroupIter{Regexp: parser.Regexp}
+	if !iter.ForEach(header, func(group string, match string) {
 		switch group {
 		case "type":
 			cc.Type = match
 		case "scope":
 			cc.Scope = match
-		case "exclamation":
-			cc.Exclamation = match == "!"
+			cc.Scopes = nil
+			for _, scope := range strings.Split(match, ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					cc.Scopes = append(cc.Scopes, scope)
+				}
+			}
+		case "exclamation", "breaking":
+			cc.Exclamation = cc.Exclamation || match != ""
 		case "description":
 			cc.Description = match
+		case "ticket":
+			cc.Ticket = match
+		}
+	}) {
+		return false
+	}
+
+	if parser.Types == nil {
+		return true
+	}
+
+	kind, ok := parser.Types[strings.ReplaceAll(cc.Type, "\uFE0F", "")]
+	if !ok {
+		return false
+	}
+
+	cc.Type = strings.TrimSuffix(kind, "!")
+	cc.Exclamation = cc.Exclamation || kind != cc.Type
+	return true
+}
+
+func (cc *ConventionalCommit) parseRevert(message string) bool {
+	iter := &utils.NamedRegexpGroupIter{Regexp: RevertRegex}
+	return iter.ForEach(message, func(group string, match string) {
+		if group == "description" {
+			cc.Type = "revert"
+			cc.Description = match
+		}
+	})
+}
+
+func (cc *ConventionalCommit) parseReverts(body string) {
+	iter := &utils.NamedRegexpGroupIter{Regexp: RevertsRegex}
+	iter.ForEach(body, func(group string, match string) {
+		if group == "hash" {
+			cc.Reverts = match
+		}
+	})
+}
+
+func (cc *ConventionalCommit) parseCherryPick(chunks []string) []string {
+	iter := &utils.NamedRegexpGroupIter{Regexp: CherryPickRegex}
+	for i, chunk := range chunks {
+		if iter.ForEach(chunk, func(group string, match string) {
+			if group == "hash" {
+				cc.CherryPick = match
+			}
+		}) {
+			return append(chunks[:i:i], chunks[i+1:]...)
+		}
+	}
+
+	return chunks
+}
+
+func (cc *ConventionalCommit) parsePullRequest() {
+	iter := &utils.NamedRegexpGroupIter{Regexp: PullRequestRegex}
+	iter.ForEach(cc.Description, func(group string, match string) {
+		if group == "number" {
+			cc.PullRequest, _ = strconv.Atoi(match)
 		}
 	})
 }
 
+func footerStart(chunks []string) int {
+	for start := range chunks {
+		if (start > 0 && strings.TrimSpace(chunks[start-1]) != "") || !FooterRegex.MatchString(chunks[start]) {
+			continue
+		}
+
+		if footersRunToEnd(chunks[start:]) {
+			return start
+		}
+	}
+
+	return len(chunks)
+}
+
+func footersRunToEnd(chunks []string) bool {
+	var breaking, paragraph bool
+	for _, chunk := range chunks {
+		switch {
+		case strings.TrimSpace(chunk) == "":
+			paragraph = true
+		case FooterRegex.MatchString(chunk):
+			key := FooterRegex.FindStringSubmatch(chunk)[FooterRegex.SubexpIndex("key")]
+			breaking = key == "BREAKING CHANGE" || key == "BREAKING-CHANGE"
+			paragraph = false
+		case paragraph && !breaking:
+			return false
+		default:
+			paragraph = false
+		}
+	}
+
+	return true
+}
+
 func (cc *ConventionalCommit) parseBodyAndFooters(chunks []string) {
 	iter := &utils.NamedRegexpGroupIter{Regexp: FooterRegex}
-	for i := len(chunks) - 1; i >= 0; i-- {
-		var key, value string
-		if !iter.ForEach(chunks[i], func(group string, match string) {
+	start := footerStart(chunks)
+
+	var key string
+	var lines []string
+	flush := func() {
+		if key != "" {
+			cc.AddFooter(key, strings.TrimSpace(strings.Join(lines, "\n")))
+		}
+	}
+
+	for _, chunk := range chunks[start:] {
+		var nextKey, value string
+		if !iter.ForEach(chunk, func(group string, match string) {
 			switch group {
 			case "key":
-				key = match
+				nextKey = match
 			case "value":
 				value = match
 			}
 		}) {
-			break
+			lines = append(lines, chunk)
+			continue
 		}
 
-		cc.AddFooter(key, value)
-		chunks = chunks[:i]
+		flush()
+		key, lines = nextKey, []string{value}
 	}
 
-	cc.Body = strings.TrimSpace(strings.Join(chunks, "\n"))
+	flush()
+	cc.Body = strings.TrimSpace(strings.Join(chunks[:start], "\n"))
 }
@@ -2,6 +2,7 @@ package conventionalcommits
 
 import (
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
 	"testing"
 )
 
@@ -32,12 +33,12 @@ wHy-nOt: true
 		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected body to be "%s", got "%s"`, commit, cc, err, "dolor sit amet", cc.Body)
 	case cc.Footers == nil:
 		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers to NOT be <nil>`, commit, cc, err)
-	case cc.Footers["my-funky-footer"] != "ok":
-		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/my-funky-footer to be "%s", got "%s"`, commit, cc, err, "ok", cc.Footers["my-funky-footer"])
-	case cc.Footers["why-not"] != "true":
-		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/why-not to be "%s", got "%s"`, commit, cc, err, "true", cc.Footers["why-not"])
-	case cc.Footers["BREAKING CHANGE"] != "it kinda broke":
-		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/BREAKING CHANGE to be "%s", got "%s"`, commit, cc, err, "it kinda broke", cc.Footers["BREAKING CHANGE"])
+	case cc.Footer("my-funky-footer") != "ok":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/my-funky-footer to be "%s", got "%s"`, commit, cc, err, "ok", cc.Footer("my-funky-footer"))
+	case cc.Footer("why-not") != "true":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/why-not to be "%s", got "%s"`, commit, cc, err, "true", cc.Footer("why-not"))
+	case cc.Footer("BREAKING CHANGE") != "it kinda broke":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/BREAKING CHANGE to be "%s", got "%s"`, commit, cc, err, "it kinda broke", cc.Footer("BREAKING CHANGE"))
 	}
 
 	commit = &object.Commit{Message: `looks : almost good`}
@@ -76,3 +77,172 @@ BREAKING-CHANGE: goteem again`})
 		t.Fatalf(`(*ConventionalCommit(%v)).IsBreakingChange(), expected false, got true`, cc)
 	}
 }
+
+func TestNewMergeConventionalCommit(t *testing.T) {
+	commit := &object.Commit{Message: `Merge pull request #12 from foo/bar
+
+feat(baz): lorem ipsum
+
+dolor sit amet`}
+
+	cc, err := NewMergeConventionalCommit(commit, true)
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewMergeConventionalCommit(%v, true) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	case cc.Type != "feat":
+		t.Fatalf(`NewMergeConventionalCommit(%v, true) = (%v, %v), expected type to be "%s", got "%s"`, commit, cc, err, "feat", cc.Type)
+	case cc.Description != "lorem ipsum":
+		t.Fatalf(`NewMergeConventionalCommit(%v, true) = (%v, %v), expected description to be "%s", got "%s"`, commit, cc, err, "lorem ipsum", cc.Description)
+	case cc.Body != "dolor sit amet":
+		t.Fatalf(`NewMergeConventionalCommit(%v, true) = (%v, %v), expected body to be "%s", got "%s"`, commit, cc, err, "dolor sit amet", cc.Body)
+	case cc.PullRequest != 12:
+		t.Fatalf(`NewMergeConventionalCommit(%v, true) = (%v, %v), expected pull request to be 12, got %d`, commit, cc, err, cc.PullRequest)
+	}
+
+	cc, err = NewMergeConventionalCommit(commit, false)
+	if err == nil {
+		t.Fatalf(`NewMergeConventionalCommit(%v, false) = (%v, %v), expected error to NOT be <nil>`, commit, cc, err)
+	}
+
+	commit = &object.Commit{Message: "fix: lorem ipsum (#34)"}
+	cc, err = NewMergeConventionalCommit(commit, false)
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewMergeConventionalCommit(%v, false) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	case cc.PullRequest != 34:
+		t.Fatalf(`NewMergeConventionalCommit(%v, false) = (%v, %v), expected pull request to be 34, got %d`, commit, cc, err, cc.PullRequest)
+	}
+
+	cc, err = NewMergeConventionalCommit(commit, true)
+	if err == nil {
+		t.Fatalf(`NewMergeConventionalCommit(%v, true) = (%v, %v), expected error to NOT be <nil>`, commit, cc, err)
+	}
+}
+
+func TestNewConventionalCommit_Revert(t *testing.T) {
+	commit := &object.Commit{Message: `Revert "feat: lorem ipsum"
+
+This reverts commit 3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4.
+`}
+
+	cc, err := NewConventionalCommit(commit)
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	case !cc.IsRevert():
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected to be a revert`, commit, cc, err)
+	case cc.Description != "feat: lorem ipsum":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected description to be "%s", got "%s"`, commit, cc, err, "feat: lorem ipsum", cc.Description)
+	case cc.Reverts != "3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected reverts to be "%s", got "%s"`, commit, cc, err, "3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4", cc.Reverts)
+	}
+
+	commit = &object.Commit{Message: "revert(foo): lorem ipsum\n\nThis reverts commit 3fa2b1c."}
+	cc, err = NewConventionalCommit(commit)
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	case cc.Reverts != "3fa2b1c":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected reverts to be "%s", got "%s"`, commit, cc, err, "3fa2b1c", cc.Reverts)
+	}
+}
+
+func TestNewConventionalCommit_MultiLineFooters(t *testing.T) {
+	commit := &object.Commit{Message: `feat: lorem ipsum
+
+dolor sit amet
+
+BREAKING CHANGE: the first line
+continues here
+
+and in another paragraph
+Refs: #1
+Co-authored-by: Jane Doe <jane@example.com>
+Co-authored-by: John Doe <john@example.com>
+Refs: #2
+`}
+
+	cc, err := NewConventionalCommit(commit)
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	case cc.Body != "dolor sit amet":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected body to be "%s", got "%s"`, commit, cc, err, "dolor sit amet", cc.Body)
+	case cc.Footer("BREAKING CHANGE") != "the first line\ncontinues here\n\nand in another paragraph":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/BREAKING CHANGE to be multi-line, got "%s"`, commit, cc, err, cc.Footer("BREAKING CHANGE"))
+	case len(cc.Footers["refs"]) != 2 || cc.Footers["refs"][0] != "#1" || cc.Footers["refs"][1] != "#2":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/refs to be [#1 #2], got %v`, commit, cc, err, cc.Footers["refs"])
+	case len(cc.Footers["co-authored-by"]) != 2:
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected 2 co-authored-by footers, got %v`, commit, cc, err, cc.Footers["co-authored-by"])
+	case !cc.IsBreakingChange():
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected to be a breaking change`, commit, cc, err)
+	}
+}
+
+func TestNewConventionalCommit_BreakingChangeParagraphs(t *testing.T) {
+	commit := &object.Commit{Message: "feat: x\n\nBREAKING CHANGE: first para\n\nsecond para\n\nRefs: #1"}
+
+	cc, err := NewConventionalCommit(commit)
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	case cc.Body != "":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected body to be empty, got "%s"`, commit, cc, err, cc.Body)
+	case cc.Footer("BREAKING CHANGE") != "first para\n\nsecond para":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/BREAKING CHANGE to span both paragraphs, got "%s"`, commit, cc, err, cc.Footer("BREAKING CHANGE"))
+	case cc.Footer("refs") != "#1":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected footers/refs to be "#1", got "%s"`, commit, cc, err, cc.Footer("refs"))
+	case !cc.IsBreakingChange():
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected to be a breaking change`, commit, cc, err)
+	}
+}
+
+func TestNewConventionalCommit_BodyParagraphWithColon(t *testing.T) {
+	commit := &object.Commit{Message: "feat: x\n\nNote: this changes the API.\n\nMore details here.\n\nRefs: #1"}
+	body := "Note: this changes the API.\n\nMore details here."
+
+	cc, err := NewConventionalCommit(commit)
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	case cc.Body != body:
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected body to be "%s", got "%s"`, commit, cc, err, body, cc.Body)
+	case cc.HasFooter("note") || len(cc.Footers) != 1 || cc.Footer("refs") != "#1":
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected the body paragraph not to be parsed as a footer, got %v`, commit, cc, err, cc.Footers)
+	}
+}
+
+func TestNewConventionalCommit_Scopes(t *testing.T) {
+	tests := map[string][]string{
+		"feat(api-v2): lorem ipsum":     {"api-v2"},
+		"fix(ui/button): lorem ipsum":   {"ui/button"},
+		"feat(core,cli): lorem ipsum":   {"core", "cli"},
+		"feat(core, cli)!: lorem ipsum": {"core", "cli"},
+		"fix(v1.2): lorem ipsum":        {"v1.2"},
+		"fix: lorem ipsum":              nil,
+	}
+
+	for message, expect := range tests {
+		commit := &object.Commit{Message: message}
+		cc, err := NewConventionalCommit(commit)
+		switch true {
+		case err != nil:
+			t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+		case strings.Join(cc.Scopes, ",") != strings.Join(expect, ","):
+			t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected scopes to be %v, got %v`, commit, cc, err, expect, cc.Scopes)
+		}
+	}
+}
+
+func TestConventionalCommit_References(t *testing.T) {
+	commit := &object.Commit{Message: "fix: lorem ipsum (JIRA-12)\n\nCloses: #1, 2\nRefs: owner/repo#3\nReviewed-by: Z"}
+	cc, err := NewConventionalCommit(commit)
+	if err != nil {
+		t.Fatalf(`NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, commit, cc, err, err)
+	}
+
+	references := cc.References()
+	if strings.Join(references, ",") != "#1,#2,owner/repo#3,JIRA-12" {
+		t.Fatalf(`cc.References() = %v, expected [#1 #2 owner/repo#3 JIRA-12]`, references)
+	}
+}
@@ -0,0 +1,132 @@
+package conventionalcommits
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bajankristof/relgen/internal/utils"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	ConventionalPreset = "conventional"
+	GitmojiPreset      = "gitmoji"
+	TicketPreset       = "ticket"
+)
+
+var (
+	GitmojiRegex = regexp.MustCompile("^(?P<type>:[a-z0-9_+-]+:|[^\\x00-\\x7F]+)\\s*(\\((?P<scope>[\\w./-]+(\\s*,\\s*[\\w./-]+)*)\\))?(?P<breaking>!)?:?\\s+(?P<description>[^ ].*)$")
+	TicketRegex  = regexp.MustCompile("(?i)^(?P<ticket>[a-z][a-z0-9]+-[0-9]+):?\\s+(?P<type>[a-z]{2,})(\\((?P<scope>[\\w./-]+(\\s*,\\s*[\\w./-]+)*)\\))?(?P<breaking>!)?: (?P<description>[^ ].*)$")
+	GitmojiTypes = map[string]string{
+		"✨": "feat", ":sparkles:": "feat",
+		"💥": "feat!", ":boom:": "feat!",
+		"🐛": "fix", ":bug:": "fix",
+		"🚑": "fix", ":ambulance:": "fix",
+		"🔒": "fix", ":lock:": "fix",
+		"📝": "docs", ":memo:": "docs",
+		"🎨": "style", ":art:": "style",
+		"♻": "refactor", ":recycle:": "refactor",
+		"⚡": "perf", ":zap:": "perf",
+		"✅": "test", ":white_check_mark:": "test",
+		"👷": "ci", ":construction_worker:": "ci",
+		"📦": "build", ":package:": "build",
+		"⬆": "build", ":arrow_up:": "build",
+		"🔧": "chore", ":wrench:": "chore",
+		"🔥": "chore", ":fire:": "chore",
+		"⏪": "revert", ":rewind:": "revert",
+	}
+)
+
+var (
+	ConventionalParser = &HeaderParser{Regexp: MessageRegex, source: ConventionalPreset}
+	GitmojiParser      = &HeaderParser{Regexp: GitmojiRegex, Types: GitmojiTypes, source: GitmojiPreset}
+	TicketParser       = &HeaderParser{Regexp: TicketRegex, source: TicketPreset}
+)
+
+type HeaderParser struct {
+	*regexp.Regexp
+	Types  map[string]string
+	source string
+}
+
+func NewHeaderParser(str string) (*HeaderParser, error) {
+	switch str {
+	case "", ConventionalPreset:
+		return ConventionalParser, nil
+	case GitmojiPreset:
+		return GitmojiParser, nil
+	case TicketPreset:
+		return TicketParser, nil
+	}
+
+	regex, err := regexp.Compile(str)
+	if err != nil {
+		return nil, err
+	}
+
+	if regex.SubexpIndex("type") < 0 || regex.SubexpIndex("description") < 0 {
+		return nil, errors.New("header parser must contain named \"type\" and \"description\" groups")
+	}
+
+	return &HeaderParser{Regexp: regex, source: str}, nil
+}
+
+func (parser *HeaderParser) NewConventionalCommit(commit *object.Commit) (*ConventionalCommit, error) {
+	chunks := strings.Split(strings.TrimRight(commit.Message, "\n"), "\n")
+	return parser.newConventionalCommit(commit, chunks)
+}
+
+func (parser *HeaderParser) NewMergeConventionalCommit(commit *object.Commit, body bool) (*ConventionalCommit, error) {
+	chunks := strings.Split(strings.TrimRight(commit.Message, "\n"), "\n")
+	number := 0
+	iter := &utils.NamedRegexpGroupIter{Regexp: MergeRegex}
+	iter.ForEach(chunks[0], func(group string, match string) {
+		if group == "number" && match != "" {
+			number, _ = strconv.Atoi(match)
+		}
+	})
+
+	if body {
+		chunks = chunks[1:]
+		for len(chunks) > 0 && strings.TrimSpace(chunks[0]) == "" {
+			chunks = chunks[1:]
+		}
+
+		if len(chunks) < 1 {
+			return nil, errors.New("merge commit has no body")
+		}
+	}
+
+	cc, err := parser.newConventionalCommit(commit, chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	if number != 0 {
+		cc.PullRequest = number
+	}
+
+	return cc, nil
+}
+
+func (parser *HeaderParser) MarshalJSON() ([]byte, error) {
+	return json.Marshal(parser.source)
+}
+
+func (parser *HeaderParser) UnmarshalJSON(bytes []byte) error {
+	str := ""
+	err := json.Unmarshal(bytes, &str)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := NewHeaderParser(str)
+	if err != nil {
+		return err
+	}
+
+	*parser = *tmp
+	return nil
+}
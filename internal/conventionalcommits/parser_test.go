@@ -0,0 +1,70 @@
+package conventionalcommits
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"testing"
+)
+
+func TestNewHeaderParser(t *testing.T) {
+	presets := map[string]*HeaderParser{
+		"":             ConventionalParser,
+		"conventional": ConventionalParser,
+		"gitmoji":      GitmojiParser,
+		"ticket":       TicketParser,
+	}
+
+	for str, expect := range presets {
+		parser, err := NewHeaderParser(str)
+		switch true {
+		case err != nil:
+			t.Fatalf(`NewHeaderParser("%s") = (%v, %v), expected error to be <nil>, got %v`, str, parser, err, err)
+		case parser != expect:
+			t.Fatalf(`NewHeaderParser("%s") = (%v, %v), expected %v, got %v`, str, parser, err, expect, parser)
+		}
+	}
+
+	for _, str := range []string{"^(?P<type>[a-z]+) (.*)$", "["} {
+		parser, err := NewHeaderParser(str)
+		if err == nil {
+			t.Fatalf(`NewHeaderParser("%s") = (%v, %v), expected error NOT to be <nil>`, str, parser, err)
+		}
+	}
+}
+
+func TestHeaderParser_NewConventionalCommit(t *testing.T) {
+	custom, _ := NewHeaderParser("^\\[(?P<type>[a-z]+)(?P<breaking>!)?\\] (?P<description>.+)$")
+	tests := []struct {
+		parser  *HeaderParser
+		message string
+		expect  ConventionalCommit
+	}{
+		{GitmojiParser, "✨ lorem ipsum", ConventionalCommit{Type: "feat", Description: "lorem ipsum"}},
+		{GitmojiParser, ":bug: (ui) lorem ipsum", ConventionalCommit{Type: "fix", Scope: "ui", Description: "lorem ipsum"}},
+		{GitmojiParser, "♻️ lorem ipsum", ConventionalCommit{Type: "refactor", Description: "lorem ipsum"}},
+		{GitmojiParser, ":boom: lorem ipsum", ConventionalCommit{Type: "feat", Exclamation: true, Description: "lorem ipsum"}},
+		{TicketParser, "PROJ-123 feat(api): lorem ipsum", ConventionalCommit{Type: "feat", Scope: "api", Ticket: "PROJ-123", Description: "lorem ipsum"}},
+		{TicketParser, "PROJ-123: fix!: lorem ipsum", ConventionalCommit{Type: "fix", Exclamation: true, Ticket: "PROJ-123", Description: "lorem ipsum"}},
+		{custom, "[feat!] lorem ipsum", ConventionalCommit{Type: "feat", Exclamation: true, Description: "lorem ipsum"}},
+	}
+
+	for _, test := range tests {
+		commit := &object.Commit{Message: test.message}
+		cc, err := test.parser.NewConventionalCommit(commit)
+		switch true {
+		case err != nil:
+			t.Fatalf(`(*HeaderParser(%v)).NewConventionalCommit(%v) = (%v, %v), expected error to be <nil>, got %v`, test.parser, commit, cc, err, err)
+		case cc.Type != test.expect.Type || cc.Scope != test.expect.Scope || cc.Exclamation != test.expect.Exclamation:
+			t.Fatalf(`(*HeaderParser(%v)).NewConventionalCommit(%v) = (%v, %v), expected %s(%s) (breaking: %v), got %s(%s) (breaking: %v)`, test.parser, commit, cc, err, test.expect.Type, test.expect.Scope, test.expect.Exclamation, cc.Type, cc.Scope, cc.Exclamation)
+		case cc.Description != test.expect.Description || cc.Ticket != test.expect.Ticket:
+			t.Fatalf(`(*HeaderParser(%v)).NewConventionalCommit(%v) = (%v, %v), expected "%s" (ticket: %s), got "%s" (ticket: %s)`, test.parser, commit, cc, err, test.expect.Description, test.expect.Ticket, cc.Description, cc.Ticket)
+		}
+	}
+
+	for _, message := range []string{"🤷 lorem ipsum", "feat: lorem ipsum"} {
+		commit := &object.Commit{Message: message}
+		cc, err := GitmojiParser.NewConventionalCommit(commit)
+		if err == nil {
+			t.Fatalf(`(*HeaderParser(%v)).NewConventionalCommit(%v) = (%v, %v), expected error NOT to be <nil>`, GitmojiParser, commit, cc, err)
+		}
+	}
+}
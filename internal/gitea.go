@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/injection"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const DefaultGiteaBaseURL = "https://gitea.com/api/v1"
+
+type GiteaPublisher struct {
+	PublisherSpec
+	Draft bool `json:"draft"`
+}
+
+func (publisher *GiteaPublisher) Publish(repository injection.Repository, rel *Release) error {
+	token := os.Getenv("GITEA_TOKEN")
+	if token == "" {
+		return errors.New("GITEA_TOKEN is not set")
+	}
+
+	repo, err := publisher.ReadRepository(repository)
+	if err != nil {
+		return err
+	}
+
+	baseURL := strings.TrimSuffix(publisher.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultGiteaBaseURL
+	}
+
+	client := publisher.newClient(http.Header{
+		"Accept":        {"application/json"},
+		"Authorization": {"token " + token},
+	})
+
+	releasesURL := fmt.Sprintf("%s/repos/%s/releases", baseURL, repo)
+	api := &gitHubReleaseAPI{
+		apiClient:   client,
+		ReleasesURL: releasesURL,
+		AssetURL: func(release *gitHubRelease, asset *gitHubAsset) string {
+			return fmt.Sprintf("%s/%d/assets/%d", releasesURL, release.ID, asset.ID)
+		},
+		Upload: func(release *gitHubRelease, name string, contents []byte) error {
+			form := &bytes.Buffer{}
+			writer := multipart.NewWriter(form)
+			part, err := writer.CreateFormFile("attachment", name)
+			if err != nil {
+				return err
+			}
+
+			if _, err = part.Write(contents); err != nil {
+				return err
+			}
+
+			if err = writer.Close(); err != nil {
+				return err
+			}
+
+			assetsURL := fmt.Sprintf("%s/%d/assets?name=%s", releasesURL, release.ID, url.QueryEscape(name))
+			_, err = client.DoRaw(http.MethodPost, assetsURL, form, writer.FormDataContentType(), nil)
+			return err
+		},
+	}
+
+	return api.Publish(&publisher.PublisherSpec, repository, rel, publisher.Draft)
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5/config"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestGiteaPublisher_Publish(t *testing.T) {
+	var created *gitHubRelease
+	uploads := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/owner/repo/releases/tags/v2.0.0":
+			w.WriteHeader(http.StatusNotFound)
+		case "POST /repos/owner/repo/releases":
+			created = &gitHubRelease{}
+			_ = json.NewDecoder(r.Body).Decode(created)
+			created.ID = 7
+			_ = json.NewEncoder(w).Encode(created)
+		case "POST /repos/owner/repo/releases/7/assets":
+			file, header, err := r.FormFile("attachment")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			data, _ := io.ReadAll(file)
+			uploads[header.Filename] = string(data)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	defer server.Close()
+	t.Setenv("GITEA_TOKEN", "secret")
+
+	repo := mocking.NewMemoryRepository()
+	_, err := repo.CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{"ssh://git@gitea.example.com:2222/owner/repo.git"}})
+	if err != nil {
+		panic(err)
+	}
+
+	asset := path.Join(t.TempDir(), "checksums.txt")
+	if err = os.WriteFile(asset, []byte("abc"), 0777); err != nil {
+		panic(err)
+	}
+
+	group := PublisherGroup{}
+	err = json.Unmarshal([]byte(fmt.Sprintf(`[{"type": "gitea", "baseUrl": "%s", "remote": "upstream", "assets": ["%s"]}]`, server.URL, asset)), &group)
+	if err != nil {
+		t.Fatalf(`json.Unmarshal(...) = %v, expected error to be <nil>`, err)
+	}
+
+	vsn, _ := semver.NewVersion("v2.0.0")
+	rel := NewRelease(vsn)
+	rel.Tag = "v2.0.0"
+	err = group.Publish(repo, rel)
+
+	switch true {
+	case err != nil:
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	case created == nil || created.TagName != "v2.0.0" || created.PreRelease:
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected a release to be created, got %+v`, group, err, created)
+	case uploads["checksums.txt"] != "abc":
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected the asset to be uploaded, got %v`, group, err, uploads)
+	}
+}
@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/injection"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const DefaultGitHubBaseURL = "https://api.github.com"
+
+var uploadURLTemplateRegex = regexp.MustCompile("\\{[^}]*\\}$")
+
+type GitHubPublisher struct {
+	PublisherSpec
+	Draft bool `json:"draft"`
+}
+
+type gitHubRelease struct {
+	ID              int64          `json:"id,omitempty"`
+	TagName         string         `json:"tag_name"`
+	TargetCommitish string         `json:"target_commitish,omitempty"`
+	Name            string         `json:"name"`
+	Body            string         `json:"body"`
+	Draft           bool           `json:"draft"`
+	PreRelease      bool           `json:"prerelease"`
+	UploadURL       string         `json:"upload_url,omitempty"`
+	Assets          []*gitHubAsset `json:"assets,omitempty"`
+}
+
+type gitHubPullRequest struct {
+	Number int64  `json:"number,omitempty"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Head   string `json:"head,omitempty"`
+	Base   string `json:"base,omitempty"`
+}
+
+type gitHubAsset struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type gitHubReleaseAPI struct {
+	*apiClient
+	ReleasesURL string
+	AssetURL    func(release *gitHubRelease, asset *gitHubAsset) string
+	Upload      func(release *gitHubRelease, name string, contents []byte) error
+}
+
+func (publisher *GitHubPublisher) Publish(repository injection.Repository, rel *Release) error {
+	client, baseURL, repo, err := publisher.open(repository)
+	if err != nil {
+		return err
+	}
+
+	api := &gitHubReleaseAPI{
+		apiClient:   client,
+		ReleasesURL: fmt.Sprintf("%s/repos/%s/releases", baseURL, repo),
+		AssetURL: func(release *gitHubRelease, asset *gitHubAsset) string {
+			return fmt.Sprintf("%s/repos/%s/releases/assets/%d", baseURL, repo, asset.ID)
+		},
+		Upload: func(release *gitHubRelease, name string, contents []byte) error {
+			uploadURL := uploadURLTemplateRegex.ReplaceAllString(release.UploadURL, "") + "?name=" + url.QueryEscape(name)
+			_, err := client.DoRaw(http.MethodPost, uploadURL, bytes.NewReader(contents), "application/octet-stream", nil)
+			return err
+		},
+	}
+
+	return api.Publish(&publisher.PublisherSpec, repository, rel, publisher.Draft)
+}
+
+func (publisher *GitHubPublisher) PublishPullRequest(repository injection.Repository, rel *Release, pr *PullRequest) error {
+	client, baseURL, repo, err := publisher.open(repository)
+	if err != nil {
+		return err
+	}
+
+	owner := strings.Split(repo, "/")[0]
+	query := url.Values{"state": {"open"}, "head": {owner + ":" + pr.Head}, "base": {pr.Base}}
+	var existing []*gitHubPullRequest
+	_, err = client.Do(http.MethodGet, fmt.Sprintf("%s/repos/%s/pulls?%s", baseURL, repo, query.Encode()), nil, &existing)
+	if err != nil {
+		return err
+	}
+
+	data := &gitHubPullRequest{Title: pr.Title, Body: pr.Body, Head: pr.Head, Base: pr.Base}
+	if len(existing) < 1 {
+		_, err = client.Do(http.MethodPost, fmt.Sprintf("%s/repos/%s/pulls", baseURL, repo), data, nil)
+		return err
+	}
+
+	data.Head, data.Base = "", ""
+	_, err = client.Do(http.MethodPatch, fmt.Sprintf("%s/repos/%s/pulls/%d", baseURL, repo, existing[0].Number), data, nil)
+	return err
+}
+
+func (publisher *GitHubPublisher) open(repository injection.Repository) (*apiClient, string, string, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, "", "", errors.New("GITHUB_TOKEN is not set")
+	}
+
+	repo, err := publisher.ReadRepository(repository)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	baseURL := strings.TrimSuffix(publisher.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultGitHubBaseURL
+	}
+
+	client := publisher.newClient(http.Header{
+		"Accept":               {"application/vnd.github+json"},
+		"Authorization":        {"Bearer " + token},
+		"X-Github-Api-Version": {"2022-11-28"},
+	})
+
+	return client, baseURL, repo, nil
+}
+
+func (api *gitHubReleaseAPI) Publish(spec *PublisherSpec, repository injection.Repository, rel *Release, draft bool) error {
+	body, err := spec.RenderBody(rel)
+	if err != nil {
+		return err
+	}
+
+	data := &gitHubRelease{
+		TagName:    rel.Tag,
+		Name:       rel.Tag,
+		Body:       body,
+		Draft:      draft,
+		PreRelease: rel.Version.IsPreRelease(),
+	}
+
+	if head, err := repository.Head(); err == nil {
+		data.TargetCommitish = head.Hash().String()
+	}
+
+	existing := &gitHubRelease{}
+	found, err := api.Find(api.ReleasesURL+"/tags/"+url.PathEscape(rel.Tag), existing)
+	if err != nil {
+		return err
+	}
+
+	result := &gitHubRelease{}
+	if !found {
+		_, err = api.Do(http.MethodPost, api.ReleasesURL, data, result)
+	} else {
+		data.TargetCommitish = ""
+		_, err = api.Do(http.MethodPatch, fmt.Sprintf("%s/%d", api.ReleasesURL, existing.ID), data, result)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	assets, err := spec.FindAssets()
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		name := filepath.Base(asset)
+		for _, other := range result.Assets {
+			if other.Name != name {
+				continue
+			}
+
+			if _, err = api.Do(http.MethodDelete, api.AssetURL(result, other), nil, nil); err != nil {
+				return err
+			}
+		}
+
+		contents, err := os.ReadFile(asset)
+		if err != nil {
+			return err
+		}
+
+		if err = api.Upload(result, name, contents); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5/config"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type gitHubStandIn struct {
+	sync.Mutex
+	*httptest.Server
+	Requests []string
+	Releases map[string]*gitHubRelease
+	Uploads  map[string]string
+}
+
+func newGitHubStandIn() *gitHubStandIn {
+	standIn := &gitHubStandIn{Releases: map[string]*gitHubRelease{}, Uploads: map[string]string{}}
+	standIn.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		standIn.Lock()
+		defer standIn.Unlock()
+		standIn.Requests = append(standIn.Requests, r.Method+" "+r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/owner/repo/releases/tags/"):
+			release, ok := standIn.Releases[strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/releases/tags/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(release)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/owner/repo/releases",
+			r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/repos/owner/repo/releases/"):
+			release := &gitHubRelease{}
+			_ = json.NewDecoder(r.Body).Decode(release)
+			release.ID = int64(len(standIn.Releases) + 1)
+			release.UploadURL = standIn.URL + "/uploads/" + release.TagName + "{?name,label}"
+			standIn.Releases[release.TagName] = release
+			_ = json.NewEncoder(w).Encode(release)
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/uploads/"):
+			data, _ := io.ReadAll(r.Body)
+			standIn.Uploads[r.URL.Query().Get("name")] = string(data)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	return standIn
+}
+
+func TestGitHubPublisher_Publish(t *testing.T) {
+	standIn := newGitHubStandIn()
+	defer standIn.Close()
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	repo := mocking.NewMemoryRepository()
+	mocking.CommitFiles(repo, "feat: foo", nil)
+	_, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/owner/repo.git"}})
+	if err != nil {
+		panic(err)
+	}
+
+	asset := path.Join(t.TempDir(), "relgen.tar.gz")
+	if err = os.WriteFile(asset, []byte("binary"), 0777); err != nil {
+		panic(err)
+	}
+
+	group := PublisherGroup{}
+	err = json.Unmarshal([]byte(fmt.Sprintf(`[{"type": "github", "baseUrl": "%s", "assets": ["%s"]}]`, standIn.URL, asset)), &group)
+	if err != nil {
+		t.Fatalf(`json.Unmarshal(...) = %v, expected error to be <nil>`, err)
+	}
+
+	vsn, _ := semver.NewVersion("1.1.0-rc.1")
+	rel := NewRelease(vsn)
+	rel.Tag = "v1.1.0-rc.1"
+	err = group.Publish(repo, rel)
+	release := standIn.Releases["v1.1.0-rc.1"]
+
+	switch true {
+	case err != nil:
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	case release == nil:
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected a release to be created, got requests %v`, group, err, standIn.Requests)
+	case !release.PreRelease || !strings.HasPrefix(release.Body, "## 1.1.0-rc.1") || release.TargetCommitish == "":
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected a pre-release with the changelog, got %+v`, group, err, release)
+	case standIn.Uploads["relgen.tar.gz"] != "binary":
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected the asset to be uploaded, got %v`, group, err, standIn.Uploads)
+	}
+
+	standIn.Requests = nil
+	if err = group.Publish(repo, rel); err != nil {
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	}
+
+	if standIn.Requests[1] != "PATCH /repos/owner/repo/releases/1" {
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected the release to be updated, got requests %v`, group, err, standIn.Requests)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	if err = group.Publish(repo, rel); err == nil {
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected error NOT to be <nil>`, group, err)
+	}
+}
+
+func TestPublisherGroup_UnmarshalJSON(t *testing.T) {
+	group := PublisherGroup{}
+	err := json.Unmarshal([]byte(`[{"type": "unknown"}]`), &group)
+	if err == nil {
+		t.Fatalf(`json.Unmarshal(...) = %v, expected error NOT to be <nil>`, err)
+	}
+}
@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/injection"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const DefaultGitLabBaseURL = "https://gitlab.com/api/v4"
+
+type GitLabPublisher struct {
+	PublisherSpec
+	Milestones []string          `json:"milestones"`
+	Links      []*GitLabLinkSpec `json:"links"`
+}
+
+type GitLabLinkSpec struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	LinkType string `json:"linkType"`
+}
+
+type gitLabRelease struct {
+	TagName     string               `json:"tag_name"`
+	Ref         string               `json:"ref,omitempty"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Milestones  []string             `json:"milestones,omitempty"`
+	Assets      *gitLabReleaseAssets `json:"assets,omitempty"`
+}
+
+type gitLabMergeRequest struct {
+	IID          int64  `json:"iid,omitempty"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	SourceBranch string `json:"source_branch,omitempty"`
+	TargetBranch string `json:"target_branch,omitempty"`
+}
+
+type gitLabReleaseAssets struct {
+	Links []*gitLabLink `json:"links"`
+}
+
+type gitLabLink struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	LinkType string `json:"link_type,omitempty"`
+}
+
+func (publisher *GitLabPublisher) Check() error {
+	if len(publisher.Assets) > 0 {
+		return errors.New("the gitlab publisher does not upload assets, use links instead")
+	}
+
+	return nil
+}
+
+func (publisher *GitLabPublisher) Publish(repository injection.Repository, rel *Release) error {
+	client, baseURL, repo, err := publisher.open(repository)
+	if err != nil {
+		return err
+	}
+
+	body, err := publisher.RenderBody(rel)
+	if err != nil {
+		return err
+	}
+
+	links, err := publisher.RenderLinks(rel)
+	if err != nil {
+		return err
+	}
+
+	milestones, err := publisher.RenderMilestones(rel)
+	if err != nil {
+		return err
+	}
+
+	releasesURL := fmt.Sprintf("%s/projects/%s/releases", baseURL, url.PathEscape(repo))
+	releaseURL := releasesURL + "/" + url.PathEscape(rel.Tag)
+	data := &gitLabRelease{
+		TagName:     rel.Tag,
+		Name:        rel.Tag,
+		Description: body,
+		Milestones:  milestones,
+	}
+
+	existing := &gitLabRelease{}
+	found, err := client.Find(releaseURL, existing)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		if head, err := repository.Head(); err == nil {
+			data.Ref = head.Hash().String()
+		}
+
+		data.Assets = &gitLabReleaseAssets{Links: links}
+		_, err = client.Do(http.MethodPost, releasesURL, data, nil)
+		return err
+	}
+
+	if _, err = client.Do(http.MethodPut, releaseURL, data, nil); err != nil {
+		return err
+	}
+
+	for _, link := range links {
+		if existing.HasLink(link.Name) {
+			continue
+		}
+
+		if _, err = client.Do(http.MethodPost, releaseURL+"/assets/links", link, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (publisher *GitLabPublisher) RenderLinks(rel *Release) ([]*gitLabLink, error) {
+	links := []*gitLabLink{}
+	for _, spec := range publisher.Links {
+		url, err := RenderString(spec.URL, rel)
+		if err != nil {
+			return nil, err
+		}
+
+		links = append(links, &gitLabLink{Name: spec.Name, URL: url, LinkType: spec.LinkType})
+	}
+
+	return links, nil
+}
+
+func (publisher *GitLabPublisher) RenderMilestones(rel *Release) ([]string, error) {
+	var milestones []string
+	for _, milestone := range publisher.Milestones {
+		str, err := RenderString(milestone, rel)
+		if err != nil {
+			return nil, err
+		}
+
+		milestones = append(milestones, str)
+	}
+
+	return milestones, nil
+}
+
+func (release *gitLabRelease) HasLink(name string) bool {
+	if release.Assets == nil {
+		return false
+	}
+
+	for _, link := range release.Assets.Links {
+		if link.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (publisher *GitLabPublisher) PublishPullRequest(repository injection.Repository, rel *Release, pr *PullRequest) error {
+	client, baseURL, repo, err := publisher.open(repository)
+	if err != nil {
+		return err
+	}
+
+	mergeRequestsURL := fmt.Sprintf("%s/projects/%s/merge_requests", baseURL, url.PathEscape(repo))
+	query := url.Values{"state": {"opened"}, "source_branch": {pr.Head}, "target_branch": {pr.Base}}
+	var existing []*gitLabMergeRequest
+	if _, err = client.Do(http.MethodGet, mergeRequestsURL+"?"+query.Encode(), nil, &existing); err != nil {
+		return err
+	}
+
+	data := &gitLabMergeRequest{Title: pr.Title, Description: pr.Body, SourceBranch: pr.Head, TargetBranch: pr.Base}
+	if len(existing) < 1 {
+		_, err = client.Do(http.MethodPost, mergeRequestsURL, data, nil)
+		return err
+	}
+
+	data.SourceBranch, data.TargetBranch = "", ""
+	_, err = client.Do(http.MethodPut, fmt.Sprintf("%s/%d", mergeRequestsURL, existing[0].IID), data, nil)
+	return err
+}
+
+func (publisher *GitLabPublisher) open(repository injection.Repository) (*apiClient, string, string, error) {
+	header := http.Header{}
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		header.Set("Private-Token", token)
+	} else if token = os.Getenv("CI_JOB_TOKEN"); token != "" {
+		header.Set("Job-Token", token)
+	} else {
+		return nil, "", "", errors.New("neither GITLAB_TOKEN nor CI_JOB_TOKEN is set")
+	}
+
+	repo, err := publisher.ReadRepository(repository)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	baseURL := strings.TrimSuffix(publisher.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = strings.TrimSuffix(os.Getenv("CI_API_V4_URL"), "/")
+	}
+
+	if baseURL == "" {
+		baseURL = DefaultGitLabBaseURL
+	}
+
+	return publisher.newClient(header), baseURL, repo, nil
+}
@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/bajankristof/relgen/internal/semver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGitLabPublisher_Publish(t *testing.T) {
+	var requests []string
+	releases := map[string]*gitLabRelease{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		if r.Header.Get("Private-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		prefix := "/projects/group%2Frepo/releases"
+		tag := strings.TrimPrefix(strings.TrimPrefix(r.URL.EscapedPath(), prefix), "/")
+		switch {
+		case r.Method == http.MethodGet && releases[tag] == nil:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(releases[tag])
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == prefix:
+			release := &gitLabRelease{}
+			_ = json.NewDecoder(r.Body).Decode(release)
+			releases[release.TagName] = release
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut, r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	defer server.Close()
+	t.Setenv("GITLAB_TOKEN", "secret")
+
+	group := PublisherGroup{}
+	err := json.Unmarshal([]byte(fmt.Sprintf(`[{
+		"type": "gitlab",
+		"baseUrl": "%s",
+		"repository": "group/repo",
+		"milestones": ["{{.Version}}"],
+		"links": [{"name": "binary", "url": "https://example.com/{{.Tag}}/relgen", "linkType": "package"}]
+	}]`, server.URL)), &group)
+	if err != nil {
+		t.Fatalf(`json.Unmarshal(...) = %v, expected error to be <nil>`, err)
+	}
+
+	vsn, _ := semver.NewVersion("1.1.0")
+	rel := NewRelease(vsn)
+	rel.Tag = "1.1.0"
+	repo := mocking.NewMemoryRepository()
+	err = group.Publish(repo, rel)
+	release := releases["1.1.0"]
+
+	switch true {
+	case err != nil:
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	case release == nil:
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected a release to be created, got requests %v`, group, err, requests)
+	case !strings.HasPrefix(release.Description, "## 1.1.0") || len(release.Milestones) != 1 || release.Milestones[0] != "1.1.0":
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected the changelog and the milestone, got %+v`, group, err, release)
+	case len(release.Assets.Links) != 1 || release.Assets.Links[0].URL != "https://example.com/1.1.0/relgen":
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected a rendered link, got %+v`, group, err, release.Assets)
+	}
+
+	release.Assets.Links = nil
+	requests = nil
+	if err = group.Publish(repo, rel); err != nil {
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	}
+
+	if strings.Join(requests, ",") != "GET /projects/group%2Frepo/releases/1.1.0,PUT /projects/group%2Frepo/releases/1.1.0,POST /projects/group%2Frepo/releases/1.1.0/assets/links" {
+		t.Fatalf(`(PublisherGroup(%v)).Publish(...) = %v, expected the release to be updated, got requests %v`, group, err, requests)
+	}
+}
+
+func TestGitLabPublisher_Check(t *testing.T) {
+	cfg := &Config{}
+	err := json.Unmarshal([]byte(`{"publishers": [{"type": "gitlab", "assets": ["dist/*"]}]}`), cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = cfg.Check(); err == nil {
+		t.Fatalf(`(*Config(%v)).Check(), expected gitlab assets to be rejected`, cfg)
+	}
+
+	cfg.Publishers = PublisherGroup{&GitLabPublisher{Links: []*GitLabLinkSpec{{Name: "docs", URL: "https://example.com"}}}}
+	if err = cfg.Check(); err != nil {
+		t.Fatalf(`(*Config(%v)).Check(), expected error to be <nil>, got %v`, cfg, err)
+	}
+}
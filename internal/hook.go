@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+)
+
+type HookSpec struct {
+	PreOutputs  []string `json:"preOutputs"`
+	PostOutputs []string `json:"postOutputs"`
+	PostTag     []string `json:"postTag"`
+}
+
+func RunHooks(commands []string, rel *Release) error {
+	if len(commands) < 1 {
+		return nil
+	}
+
+	data, err := MarshalRelease(rel)
+	if err != nil {
+		return err
+	}
+
+	env := append(os.Environ(), HookEnv(rel)...)
+	for _, command := range commands {
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Env = env
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err = cmd.Run(); err != nil {
+			return fmt.Errorf("hook \"%s\" failed: %w", command, err)
+		}
+	}
+
+	return nil
+}
+
+func HookEnv(rel *Release) []string {
+	var previous string
+	if rel.Statistics.PreviousVersion != nil {
+		previous = rel.Statistics.PreviousVersion.String()
+	}
+
+	return []string{
+		"RELGEN_VERSION=" + rel.Version.String(),
+		"RELGEN_TAG=" + rel.Tag,
+		"RELGEN_PREVIOUS_VERSION=" + previous,
+		"RELGEN_BUMP=" + rel.Bump,
+		"RELGEN_IS_PRERELEASE=" + strconv.FormatBool(rel.Version.IsPreRelease()),
+	}
+}
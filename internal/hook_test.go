@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/semver"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestRunHooks(t *testing.T) {
+	out := path.Join(t.TempDir(), "out.txt")
+	previous, _ := semver.NewVersion("1.1.0")
+	vsn, _ := semver.NewVersion("1.2.0-rc.1")
+	rel := NewRelease(vsn)
+	rel.Bump = semver.MINOR
+	rel.Statistics.PreviousVersion = previous
+
+	commands := []string{
+		`echo "$RELGEN_VERSION $RELGEN_PREVIOUS_VERSION $RELGEN_BUMP $RELGEN_IS_PRERELEASE" > ` + out,
+		`head -c 12 >> ` + out,
+	}
+
+	err := RunHooks(commands, rel)
+	data, _ := os.ReadFile(out)
+
+	switch true {
+	case err != nil:
+		t.Fatalf(`RunHooks(%v, %v) = %v, expected error to be <nil>, got %v`, commands, rel, err, err)
+	case string(data) != "1.2.0-rc.1 1.1.0 MINOR true\n{\"schema\":1,":
+		t.Fatalf(`RunHooks(%v, %v) = %v, expected the environment and the release on stdin, got "%s"`, commands, rel, err, data)
+	}
+
+	commands = []string{"exit 3", "echo unreachable > " + out}
+	err = RunHooks(commands, rel)
+	data, _ = os.ReadFile(out)
+	if err == nil || string(data) == "unreachable\n" {
+		t.Fatalf(`RunHooks(%v, %v) = %v, expected to stop with an error`, commands, rel, err)
+	}
+}
@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"bufio"
+	"errors"
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"github.com/bajankristof/relgen/internal/injection"
+	"github.com/bajankristof/relgen/internal/utils"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultIgnoreFile   = ".relgenignore"
+	DefaultIgnoreFooter = "relgen-off"
+)
+
+type IgnoreSpec struct {
+	Authors []string `json:"authors"`
+	Paths   []string `json:"paths"`
+	Hashes  []string `json:"hashes"`
+	File    string   `json:"file"`
+	Footer  string   `json:"footer"`
+}
+
+func (spec *IgnoreSpec) Check() error {
+	for _, pattern := range spec.Authors {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return err
+		}
+	}
+
+	if spec.Footer == "" {
+		spec.Footer = DefaultIgnoreFooter
+	}
+
+	return nil
+}
+
+func (spec *IgnoreSpec) ReadFile(dir string) error {
+	name := spec.File
+	if name == "" {
+		name = DefaultIgnoreFile
+	}
+
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(dir, name)
+	}
+
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) && spec.File == "" {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && !strings.HasPrefix(fields[0], "#") {
+			spec.Hashes = append(spec.Hashes, fields[0])
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (spec *IgnoreSpec) Ignores(repository injection.Repository, cc *conventionalcommits.ConventionalCommit) (bool, error) {
+	footer := spec.Footer
+	if footer == "" {
+		footer = DefaultIgnoreFooter
+	}
+
+	if cc.HasFooter(strings.ToLower(footer)) || cc.HasFooter(strings.ToLower(ReleaseFooter)) {
+		return true, nil
+	}
+
+	for _, hash := range spec.Hashes {
+		if len(hash) >= 7 && strings.HasPrefix(cc.Hash.String(), strings.ToLower(hash)) {
+			return true, nil
+		}
+	}
+
+	for _, pattern := range spec.Authors {
+		for _, email := range []string{cc.Author.Email, cc.Committer.Email} {
+			if matched, _ := regexp.MatchString(pattern, email); matched {
+				return true, nil
+			}
+		}
+	}
+
+	if len(spec.Paths) < 1 {
+		return false, nil
+	}
+
+	changes, err := injection.CommitChanges(repository, cc.Commit)
+	if err != nil || len(changes) < 1 {
+		return false, err
+	}
+
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" && !spec.matchesPath(name) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (spec *IgnoreSpec) matchesPath(name string) bool {
+	for _, pattern := range spec.Paths {
+		if utils.MatchGlob(pattern, name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/mocking"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestIgnoreSpec_ReadFile(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(path.Join(dir, DefaultIgnoreFile), []byte("# bad history\n1fa2b1c4d5e6f708 broken merge\n\n2fa2b1c\n"), 0777)
+	if err != nil {
+		panic(err)
+	}
+
+	spec := &IgnoreSpec{}
+	err = spec.ReadFile(dir)
+	switch true {
+	case err != nil:
+		t.Fatalf(`(*IgnoreSpec(%v)).ReadFile("%s") = %v, expected error to be <nil>, got %v`, spec, dir, err, err)
+	case len(spec.Hashes) != 2 || spec.Hashes[0] != "1fa2b1c4d5e6f708" || spec.Hashes[1] != "2fa2b1c":
+		t.Fatalf(`(*IgnoreSpec(%v)).ReadFile("%s") = %v, expected hashes to be [1fa2b1c4d5e6f708 2fa2b1c], got %v`, spec, dir, err, spec.Hashes)
+	}
+
+	spec = &IgnoreSpec{}
+	if err = spec.ReadFile(t.TempDir()); err != nil {
+		t.Fatalf(`(*IgnoreSpec(%v)).ReadFile(...) = %v, expected error to be <nil>, got %v`, spec, err, err)
+	}
+
+	spec = &IgnoreSpec{File: "missing"}
+	if err = spec.ReadFile(t.TempDir()); err == nil {
+		t.Fatalf(`(*IgnoreSpec(%v)).ReadFile(...) = %v, expected error NOT to be <nil>`, spec, err)
+	}
+}
+
+func TestReleaseBuilder_BuildSinceIgnore(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	mocking.CommitFiles(repo, "chore: init", map[string]string{"main.go": "package main\n"})
+	mocking.CommitFiles(repo, "fix: typo in docs", map[string]string{"docs/guide/intro.md": "foo\n", "README.md": "foo\n"})
+	mocking.CommitFiles(repo, "fix: code and docs", map[string]string{"docs/api.md": "foo\n", "main.go": "package main\n\nfunc main() {}\n"})
+	skipped := mocking.CommitFiles(repo, "feat: bad history", map[string]string{"a.go": "package main\n"})
+	mocking.CommitFiles(repo, "feat: skipped\n\nSkip-release: true", map[string]string{"b.go": "package main\n"})
+
+	cfg := &Config{
+		Ignore:     IgnoreSpec{Paths: []string{"docs/**", "*.md"}, Hashes: []string{skipped.String()[:8]}, Footer: "Skip-Release"},
+		ChangeSpec: DefaultChangeSpec,
+	}
+
+	builder := NewReleaseBuilder(repo, cfg)
+	rel, err := builder.BuildSince(nil)
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case len(rel.Changelog["Features"]) != 0:
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected no features, got %v", builder, rel, err, rel.Changelog["Features"])
+	case len(rel.Changelog["Fixes"]) != 1 || rel.Changelog["Fixes"][0].Description != "code and docs":
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected a single fix, got %v", builder, rel, err, rel.Changelog["Fixes"])
+	}
+
+	builder.Config = &Config{Ignore: IgnoreSpec{Authors: []string{"@example\\.com$"}}, ChangeSpec: DefaultChangeSpec}
+	rel, err = builder.BuildSince(nil)
+	if len(rel.Changelog) != 0 {
+		t.Fatalf("(*ReleaseBuilder(%v)).BuildSince(nil) = (%v, %v), expected an empty changelog, got %v", builder, rel, err, rel.Changelog)
+	}
+}
@@ -1,6 +1,7 @@
 package injection
 
 import (
+	"errors"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -8,13 +9,25 @@ import (
 )
 
 type Repository interface {
+	Head() (*plumbing.Reference, error)
+	CommitObject(hash plumbing.Hash) (*object.Commit, error)
 	Log(options *git.LogOptions) (object.CommitIter, error)
 	Tags() (storer.ReferenceIter, error)
+	TreeObject(hash plumbing.Hash) (*object.Tree, error)
+	TagObject(hash plumbing.Hash) (*object.Tag, error)
+	Remote(name string) (*git.Remote, error)
+	Worktree() (*git.Worktree, error)
+	CreateTag(name string, hash plumbing.Hash, options *git.CreateTagOptions) (*plumbing.Reference, error)
 }
 
 type NonMergeCommitIter struct {
 	cache    map[plumbing.Hash]bool
 	MaxDepth uint
+	Merges   bool
+}
+
+type FirstParentCommitIter struct {
+	Repository Repository
 }
 
 func (iter *NonMergeCommitIter) ForEach(commits object.CommitIter, callback func(commit *object.Commit) error) error {
@@ -34,6 +47,12 @@ func (iter *NonMergeCommitIter) deepForEach(commits object.CommitIter, callback
 			return callback(commit)
 		}
 
+		if iter.Merges {
+			if err := callback(commit); err != nil {
+				return err
+			}
+		}
+
 		if depth < iter.MaxDepth {
 			return iter.deepForEach(commit.Parents(), callback, depth+1)
 		}
@@ -41,3 +60,27 @@ func (iter *NonMergeCommitIter) deepForEach(commits object.CommitIter, callback
 		return nil
 	})
 }
+
+func (iter *FirstParentCommitIter) ForEach(commit *object.Commit, callback func(commit *object.Commit) error) error {
+	for commit != nil {
+		if err := callback(commit); err != nil {
+			return err
+		}
+
+		if commit.NumParents() < 1 {
+			return nil
+		}
+
+		var err error
+		commit, err = iter.Repository.CommitObject(commit.ParentHashes[0])
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
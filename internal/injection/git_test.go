@@ -3,8 +3,10 @@ package injection
 import (
 	"errors"
 	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
 	"testing"
 )
 
@@ -60,3 +62,92 @@ func TestNonMergeCommitIter_deepForEach(t *testing.T) {
 		t.Fatalf("(*NonMergeCommitIter(%v)).deepForEach(...), expected number of iterations to be %v, got %v", iter, 0, got)
 	}
 }
+
+func TestNonMergeCommitIter_ForEachWithMerges(t *testing.T) {
+	log := &mocking.MockCommitIter{Commits: []*object.Commit{
+		{Message: "foo", Hash: plumbing.NewHash("123"), ParentHashes: []plumbing.Hash{plumbing.NewHash("111"), plumbing.NewHash("222")}},
+		{Message: "bar", Hash: plumbing.NewHash("456"), ParentHashes: []plumbing.Hash{}},
+	}}
+
+	iter := &NonMergeCommitIter{Merges: true}
+
+	var commits []*object.Commit
+	err := iter.ForEach(log, func(commit *object.Commit) error {
+		commits = append(commits, commit)
+		return nil
+	})
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*NonMergeCommitIter(%v)).ForEach(...) = %v, expected error to be <nil>, got %v", iter, err, err)
+	case len(commits) != 2:
+		t.Fatalf("(*NonMergeCommitIter(%v)).ForEach(...) = %v, expected number of iterations to be %v, got %v", iter, err, 2, len(commits))
+	}
+}
+
+func TestFirstParentCommitIter_ForEach(t *testing.T) {
+	repo := &mocking.MockRepository{LogReturn: &mocking.MockCommitIter{Commits: []*object.Commit{
+		{Message: "foo", Hash: plumbing.NewHash("123"), ParentHashes: []plumbing.Hash{plumbing.NewHash("456"), plumbing.NewHash("789")}},
+		{Message: "bar", Hash: plumbing.NewHash("456"), ParentHashes: []plumbing.Hash{plumbing.NewHash("abc")}},
+		{Message: "baz", Hash: plumbing.NewHash("789"), ParentHashes: []plumbing.Hash{plumbing.NewHash("abc")}},
+		{Message: "qux", Hash: plumbing.NewHash("abc"), ParentHashes: []plumbing.Hash{plumbing.NewHash("def")}},
+	}}}
+
+	iter := &FirstParentCommitIter{Repository: repo}
+
+	var messages []string
+	err := iter.ForEach(repo.LogReturn.Commits[0], func(commit *object.Commit) error {
+		messages = append(messages, commit.Message)
+		return nil
+	})
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*FirstParentCommitIter(%v)).ForEach(...) = %v, expected error to be <nil>, got %v", iter, err, err)
+	case strings.Join(messages, ",") != "foo,bar,qux":
+		t.Fatalf("(*FirstParentCommitIter(%v)).ForEach(...) = %v, expected to iterate foo,bar,qux, got %v", iter, err, strings.Join(messages, ","))
+	}
+}
+
+func TestPatchID(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	root := mocking.CommitFiles(repo, "chore: init", map[string]string{"a.txt": "a\n"})
+	original := mocking.CommitFiles(repo, "feat: foo", map[string]string{"foo.txt": "foo\n"})
+	other := mocking.CommitFiles(repo, "feat: bar", map[string]string{"foo.txt": "bar\n"})
+	err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/original", original))
+	if err != nil {
+		t.Fatalf("SetReference(...), expected error to be <nil>, got %v", err)
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree(), expected error to be <nil>, got %v", err)
+	}
+
+	if err = worktree.Checkout(&git.CheckoutOptions{Hash: root, Branch: "refs/heads/backport", Create: true}); err != nil {
+		t.Fatalf("Checkout(...), expected error to be <nil>, got %v", err)
+	}
+
+	picked := mocking.CommitFiles(repo, "feat: foo", map[string]string{"foo.txt": "foo\n", "a.txt": "a\n"})
+
+	ids := map[plumbing.Hash]plumbing.Hash{}
+	for _, hash := range []plumbing.Hash{original, other, picked} {
+		commit, _ := repo.CommitObject(hash)
+		ids[hash], err = PatchID(repo, commit)
+		if err != nil {
+			t.Fatalf("PatchID(%v) = (%v, %v), expected error to be <nil>, got %v", commit, ids[hash], err, err)
+		}
+	}
+
+	empty, _ := repo.CommitObject(mocking.CommitFiles(repo, "chore: empty", nil))
+	if id, err := PatchID(repo, empty); err != nil || !id.IsZero() {
+		t.Fatalf("PatchID(%v) = (%v, %v), expected the patch-id of an empty commit to be zero", empty, id, err)
+	}
+
+	switch true {
+	case ids[original] != ids[picked]:
+		t.Fatalf("PatchID(...), expected patch-id of %v and %v to be equal, got %v and %v", original, picked, ids[original], ids[picked])
+	case ids[original] == ids[other]:
+		t.Fatalf("PatchID(...), expected patch-id of %v and %v to differ, got %v", original, other, ids[original])
+	}
+}
@@ -0,0 +1,77 @@
+package injection
+
+import (
+	"crypto/sha1"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
+)
+
+func CommitChanges(repository Repository, commit *object.Commit) (object.Changes, error) {
+	tree, err := repository.TreeObject(commit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := repository.CommitObject(commit.ParentHashes[0])
+		if err != nil {
+			return nil, err
+		}
+
+		parentTree, err = repository.TreeObject(parent.TreeHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return object.DiffTree(parentTree, tree)
+}
+
+func PatchID(repository Repository, commit *object.Commit) (plumbing.Hash, error) {
+	changes, err := CommitChanges(repository, commit)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	patch, err := changes.Patch()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if len(patch.FilePatches()) < 1 {
+		return plumbing.ZeroHash, nil
+	}
+
+	hasher := sha1.New()
+	for _, filePatch := range patch.FilePatches() {
+		from, to := filePatch.Files()
+		for _, file := range []diff.File{from, to} {
+			if file == nil {
+				hasher.Write([]byte("/dev/null\n"))
+				continue
+			}
+
+			hasher.Write([]byte(file.Path() + "\n"))
+			if filePatch.IsBinary() {
+				hash := file.Hash()
+				hasher.Write(hash[:])
+			}
+		}
+
+		for _, chunk := range filePatch.Chunks() {
+			if chunk.Type() == diff.Equal {
+				continue
+			}
+
+			hasher.Write([]byte{byte('0' + chunk.Type())})
+			hasher.Write([]byte(strings.Join(strings.Fields(chunk.Content()), "")))
+		}
+	}
+
+	var hash plumbing.Hash
+	copy(hash[:], hasher.Sum(nil))
+	return hash, nil
+}
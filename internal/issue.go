@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"encoding/json"
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+var DefaultIssuePatterns = IssuePatternGroup{
+	{Regexp: regexp.MustCompile("^(?P<repository>[\\w.-]+/[\\w.-]+)?#(?P<number>[0-9]+)$")},
+}
+
+type IssuePatternGroup []*IssuePattern
+
+type IssuePattern struct {
+	*regexp.Regexp
+	URL *template.Template
+}
+
+func (group IssuePatternGroup) FindIssues(cc *conventionalcommits.ConventionalCommit) []*conventionalcommits.Issue {
+	var issues []*conventionalcommits.Issue
+	seen := map[string]bool{}
+	for _, reference := range cc.References() {
+		for _, pattern := range group {
+			issue := pattern.Match(reference)
+			if issue == nil {
+				continue
+			}
+
+			if !seen[issue.ID] {
+				seen[issue.ID] = true
+				issues = append(issues, issue)
+			}
+
+			break
+		}
+	}
+
+	return issues
+}
+
+func (pattern *IssuePattern) Match(reference string) *conventionalcommits.Issue {
+	match := pattern.FindStringSubmatch(reference)
+	if match == nil || match[0] != reference {
+		return nil
+	}
+
+	issue := &conventionalcommits.Issue{ID: reference}
+	if pattern.URL == nil {
+		return issue
+	}
+
+	data := map[string]string{"ID": reference}
+	for i, group := range pattern.SubexpNames() {
+		if i > 0 && group != "" {
+			data[group] = match[i]
+		}
+	}
+
+	str := &strings.Builder{}
+	if err := pattern.URL.Execute(str, data); err == nil {
+		issue.URL = str.String()
+	}
+
+	return issue
+}
+
+func (pattern *IssuePattern) UnmarshalJSON(data []byte) error {
+	tmp := &struct {
+		Pattern string `json:"pattern"`
+		URL     string `json:"url"`
+	}{}
+
+	err := json.Unmarshal(data, tmp)
+	if err != nil {
+		return err
+	}
+
+	pattern.Regexp, err = regexp.Compile(tmp.Pattern)
+	if err != nil {
+		return err
+	}
+
+	if tmp.URL != "" {
+		pattern.URL, err = template.New("url").Parse(tmp.URL)
+	}
+
+	return err
+}
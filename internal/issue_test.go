@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"encoding/json"
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"testing"
+)
+
+func TestIssuePatternGroup_FindIssues(t *testing.T) {
+	group := IssuePatternGroup{}
+	err := json.Unmarshal([]byte(`[
+		{"pattern": "^(?P<key>[A-Z]+)-(?P<number>[0-9]+)$", "url": "https://jira.example.com/browse/{{.ID}}"},
+		{"pattern": "^#(?P<number>[0-9]+)$", "url": "https://github.com/owner/repo/issues/{{.number}}"}
+	]`), &group)
+	if err != nil {
+		t.Fatalf(`json.Unmarshal(...) = %v, expected error to be <nil>`, err)
+	}
+
+	commit := &object.Commit{Message: "fix: lorem ipsum (JIRA-12)\n\nCloses: #1, #1\nRefs: other"}
+	cc, _ := conventionalcommits.NewConventionalCommit(commit)
+	issues := group.FindIssues(cc)
+	switch true {
+	case len(issues) != 2:
+		t.Fatalf(`group.FindIssues(%v) = %v, expected 2 issues, got %d`, cc, issues, len(issues))
+	case issues[0].ID != "#1" || issues[0].URL != "https://github.com/owner/repo/issues/1":
+		t.Fatalf(`group.FindIssues(%v) = %v, expected issues[0] to be #1, got %v`, cc, issues, issues[0])
+	case issues[1].ID != "JIRA-12" || issues[1].URL != "https://jira.example.com/browse/JIRA-12":
+		t.Fatalf(`group.FindIssues(%v) = %v, expected issues[1] to be JIRA-12, got %v`, cc, issues, issues[1])
+	}
+
+	issues = DefaultIssuePatterns.FindIssues(cc)
+	switch true {
+	case len(issues) != 1:
+		t.Fatalf(`DefaultIssuePatterns.FindIssues(%v) = %v, expected 1 issue, got %d`, cc, issues, len(issues))
+	case issues[0].ID != "#1" || issues[0].URL != "":
+		t.Fatalf(`DefaultIssuePatterns.FindIssues(%v) = %v, expected issues[0] to be #1, got %v`, cc, issues, issues[0])
+	}
+}
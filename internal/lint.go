@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"fmt"
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
+)
+
+func (builder *ReleaseBuilder) Lint() ([]string, error) {
+	version, err := builder.ReadCurrentVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []string
+	err = builder.ForEachCommit(func(commit *object.Commit) error {
+		if version != nil && version.IsReference(commit.Hash) {
+			return errBreak
+		}
+
+		cc, err := builder.NewConventionalCommit(commit)
+		conventional := err == nil
+		if !conventional {
+			cc = conventionalcommits.NewNonConventionalCommit(commit)
+		}
+
+		ignored, err := builder.Config.Ignore.Ignores(builder.Repository, cc)
+		if err != nil || ignored {
+			return err
+		}
+
+		issue := fmt.Sprintf("\"%s\" is not a conventional commit", cc.Description)
+		if conventional {
+			issue = builder.LintCommit(cc)
+		}
+
+		if issue != "" {
+			issues = append(issues, commit.Hash.String()[:7]+": "+issue)
+		}
+
+		return nil
+	})
+
+	if err != nil && err != errBreak {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+func (builder *ReleaseBuilder) LintCommit(cc *conventionalcommits.ConventionalCommit) string {
+	if _, spec := builder.Config.FindChangeSpec(cc); spec != nil || cc.IsRevert() {
+		return builder.lintScopes(cc)
+	}
+
+	if suggestion := builder.Config.SuggestType(cc.Type); suggestion != "" {
+		return fmt.Sprintf("`%s` looks like `%s`", cc.Type, suggestion)
+	}
+
+	return fmt.Sprintf("`%s` is not a recognized type", cc.Type)
+}
+
+func (builder *ReleaseBuilder) lintScopes(cc *conventionalcommits.ConventionalCommit) string {
+	allowed := builder.Config.AllowedScopes
+	if len(allowed) < 1 {
+		return ""
+	}
+
+	if scope := builder.Config.DisallowedScope(cc); scope != "" {
+		return fmt.Sprintf("`%s` is not an allowed scope (allowed: %s)", scope, strings.Join(allowed, ", "))
+	}
+
+	if len(cc.Scopes) < 1 {
+		return fmt.Sprintf("missing scope (allowed: %s)", strings.Join(allowed, ", "))
+	}
+
+	return ""
+}
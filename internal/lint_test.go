@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"testing"
+)
+
+func TestReleaseBuilder_Lint(t *testing.T) {
+	repo := &mocking.MockRepository{
+		TagsReturn: &mocking.MockReferenceIter{
+			References: []*plumbing.Reference{
+				plumbing.NewHashReference("1.0.0", plumbing.NewHash("5fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4")),
+			},
+		},
+		LogReturn: &mocking.MockCommitIter{Commits: []*object.Commit{
+			{Message: "fature: typo", Hash: plumbing.NewHash("1fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "Feature(api): aliased", Hash: plumbing.NewHash("2fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "wip", Hash: plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "whatever: unknown", Hash: plumbing.NewHash("4fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "wip\n\nrelgen-off: true", Hash: plumbing.NewHash("6fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "Release 1.0.1\n\nRelgen-Release: 1.0.1", Hash: plumbing.NewHash("7fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "whatever: ignored by hash", Hash: plumbing.NewHash("8fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fix(docs): disallowed scope", Hash: plumbing.NewHash("9fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fix: missing scope", Hash: plumbing.NewHash("afa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fix(API): allowed scope", Hash: plumbing.NewHash("bfa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+			{Message: "fature: released", Hash: plumbing.NewHash("5fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"), ParentHashes: []plumbing.Hash{}},
+		}},
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec, TypeAliases: map[string]string{"feature": "feat"}, AllowedScopes: []string{"api"}, Ignore: IgnoreSpec{Hashes: []string{"8fa2b1c"}}})
+	issues, err := builder.Lint()
+	expect := []string{
+		"1fa2b1c: `fature` looks like `feat`",
+		"3fa2b1c: \"wip\" is not a conventional commit",
+		"4fa2b1c: `whatever` is not a recognized type",
+		"9fa2b1c: `docs` is not an allowed scope (allowed: api)",
+		"afa2b1c: missing scope (allowed: api)",
+	}
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).Lint() = (%v, %v), expected error to be <nil>, got %v", builder, issues, err, err)
+	case len(issues) != len(expect):
+		t.Fatalf("(*ReleaseBuilder(%v)).Lint() = (%v, %v), expected %v, got %v", builder, issues, err, expect, issues)
+	}
+
+	for i := range expect {
+		if issues[i] != expect[i] {
+			t.Fatalf("(*ReleaseBuilder(%v)).Lint() = (%v, %v), expected %v, got %v", builder, issues, err, expect, issues)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/semver"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const MetadataTimeFormat = "20060102150405"
+
+type MetadataContext struct {
+	Hash           string
+	ShortHash      string
+	CommitDate     string
+	BuildTimestamp string
+	CommitCount    int
+	Env            map[string]string
+}
+
+func NewMetadataContext() *MetadataContext {
+	env := map[string]string{}
+	for _, pair := range os.Environ() {
+		key, value, _ := strings.Cut(pair, "=")
+		env[key] = value
+	}
+
+	return &MetadataContext{
+		BuildTimestamp: time.Now().UTC().Format(MetadataTimeFormat),
+		Env:            env,
+	}
+}
+
+func (builder *ReleaseBuilder) RenderBuildMetadata(rel *Release) (string, error) {
+	if !strings.Contains(builder.Config.BuildMetadata, "{{") {
+		return builder.Config.BuildMetadata, semver.CheckMetadata(builder.Config.BuildMetadata)
+	}
+
+	tpl, err := template.New("buildMetadata").Option("missingkey=zero").Parse(builder.Config.BuildMetadata)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := builder.ReadHead()
+	if err != nil {
+		return "", err
+	}
+
+	ctx := NewMetadataContext()
+	ctx.CommitCount = rel.CommitCount()
+	if head != nil {
+		ctx.Hash = head.Hash.String()
+		ctx.ShortHash = ctx.Hash[:7]
+		ctx.CommitDate = head.Committer.When.UTC().Format(MetadataTimeFormat)
+	}
+
+	str := &strings.Builder{}
+	err = tpl.Execute(str, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	metadata := strings.TrimSpace(str.String())
+	return metadata, semver.CheckMetadata(metadata)
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"testing"
+	"time"
+)
+
+func TestReleaseBuilder_RenderBuildMetadata(t *testing.T) {
+	head := &object.Commit{
+		Hash:      plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"),
+		Committer: object.Signature{When: time.Date(2023, 7, 1, 12, 30, 0, 0, time.UTC)},
+	}
+
+	repo := &mocking.MockRepository{
+		HeadReturn: plumbing.NewHashReference("HEAD", head.Hash),
+		LogReturn:  &mocking.MockCommitIter{Commits: []*object.Commit{head}},
+	}
+
+	t.Setenv("RELGEN_TEST_BUILD", "42")
+	builder := NewReleaseBuilder(repo, &Config{BuildMetadata: "{{.ShortHash}}.{{.CommitCount}}.{{.CommitDate}}.{{.Env.RELGEN_TEST_BUILD}}"})
+	rel := NewRelease(nil)
+	rel.Statistics.Commits = 17
+
+	metadata, err := builder.RenderBuildMetadata(rel)
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).RenderBuildMetadata(%v) = (%v, %v), expected error to be <nil>, got %v", builder, rel, metadata, err, err)
+	case metadata != "3fa2b1c.17.20230701123000.42":
+		t.Fatalf(`(*ReleaseBuilder(%v)).RenderBuildMetadata(%v) = (%v, %v), expected "3fa2b1c.17.20230701123000.42", got "%s"`, builder, rel, metadata, err, metadata)
+	}
+}
+
+func TestReleaseBuilder_RenderBuildMetadataError(t *testing.T) {
+	builder := NewReleaseBuilder(&mocking.MockRepository{}, &Config{BuildMetadata: "{{.Env.RELGEN_TEST_MISSING}}.foo bar"})
+	rel := NewRelease(nil)
+
+	metadata, err := builder.RenderBuildMetadata(rel)
+	if err == nil {
+		t.Fatalf("(*ReleaseBuilder(%v)).RenderBuildMetadata(%v) = (%v, %v), expected error NOT to be <nil>", builder, rel, metadata, err)
+	}
+
+	builder.Config.BuildMetadata = "foo..bar"
+	metadata, err = builder.RenderBuildMetadata(rel)
+	if err == nil {
+		t.Fatalf("(*ReleaseBuilder(%v)).RenderBuildMetadata(%v) = (%v, %v), expected error NOT to be <nil>", builder, rel, metadata, err)
+	}
+}
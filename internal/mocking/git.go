@@ -8,6 +8,7 @@ import (
 )
 
 type MockRepository struct {
+	HeadReturn *plumbing.Reference
 	LogReturn  *MockCommitIter
 	LogCalls   []*git.LogOptions
 	TagsReturn *MockReferenceIter
@@ -23,6 +24,52 @@ type MockCommitIter struct {
 	Commits []*object.Commit
 }
 
+func (repo *MockRepository) Head() (*plumbing.Reference, error) {
+	if repo.HeadReturn == nil {
+		return nil, plumbing.ErrReferenceNotFound
+	}
+
+	return repo.HeadReturn, nil
+}
+
+func (repo *MockRepository) CommitObject(hash plumbing.Hash) (*object.Commit, error) {
+	if repo.LogReturn != nil {
+		for _, commit := range repo.LogReturn.Commits {
+			if commit.Hash == hash {
+				return commit, nil
+			}
+		}
+	}
+
+	return nil, plumbing.ErrObjectNotFound
+}
+
+func (repo *MockRepository) TreeObject(hash plumbing.Hash) (*object.Tree, error) {
+	return nil, plumbing.ErrObjectNotFound
+}
+
+func (repo *MockRepository) TagObject(hash plumbing.Hash) (*object.Tag, error) {
+	return nil, plumbing.ErrObjectNotFound
+}
+
+func (repo *MockRepository) Remote(name string) (*git.Remote, error) {
+	return nil, git.ErrRemoteNotFound
+}
+
+func (repo *MockRepository) Worktree() (*git.Worktree, error) {
+	return nil, git.ErrIsBareRepository
+}
+
+func (repo *MockRepository) CreateTag(name string, hash plumbing.Hash, options *git.CreateTagOptions) (*plumbing.Reference, error) {
+	ref := plumbing.NewHashReference(plumbing.NewTagReferenceName(name), hash)
+	if repo.TagsReturn == nil {
+		repo.TagsReturn = &MockReferenceIter{}
+	}
+
+	repo.TagsReturn.References = append(repo.TagsReturn.References, ref)
+	return ref, nil
+}
+
 func (repo *MockRepository) Log(options *git.LogOptions) (object.CommitIter, error) {
 	if repo.LogReturn.Error != nil {
 		return nil, repo.LogReturn.Error
@@ -0,0 +1,64 @@
+package mocking
+
+import (
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"time"
+)
+
+var Signature = &object.Signature{
+	Name:  "Jane Doe",
+	Email: "jane@example.com",
+	When:  time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func NewMemoryRepository() *git.Repository {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+func CommitFiles(repo *git.Repository, message string, files map[string]string, parents ...plumbing.Hash) plumbing.Hash {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		panic(err)
+	}
+
+	for path, content := range files {
+		file, err := worktree.Filesystem.Create(path)
+		if err != nil {
+			panic(err)
+		}
+
+		_, err = file.Write([]byte(content))
+		if err != nil {
+			panic(err)
+		}
+
+		if err = file.Close(); err != nil {
+			panic(err)
+		}
+
+		if _, err = worktree.Add(path); err != nil {
+			panic(err)
+		}
+	}
+
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		Author:            Signature,
+		Parents:           parents,
+		AllowEmptyCommits: true,
+	})
+
+	if err != nil {
+		panic(err)
+	}
+
+	return hash
+}
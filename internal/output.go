@@ -18,6 +18,9 @@ var DefaultChangelogOutput = &OutputWriter{
 	Template: template.Must(template.New("changelog-entry.md").Parse(`## {{.Version | print}} ({{.Date.Format "2006-01-02"}}){{range $category, $changes := .Changelog}}
 ### {{$category}}{{range $cc := $changes}}
 * {{$cc.Description}} (#{{printf "%.*s" 8 $cc.Hash}}){{end}}
+{{end}}{{if .Contributors}}
+### Contributors{{range $contributor := .Contributors}}
+* {{$contributor.Name}}{{end}}
 {{end}}`)),
 }
 
@@ -46,9 +49,9 @@ func (group OutputWriterGroup) Execute(rel *Release) error {
 	return errs.Wait()
 }
 
-func (writer *OutputWriter) Execute(rel *Release) error {
+func (writer *OutputWriter) Execute(rel *Release) (err error) {
 	dir := filepath.Dir(writer.Path)
-	err := os.MkdirAll(dir, 0777)
+	err = os.MkdirAll(dir, 0777)
 	if err != nil {
 		return err
 	}
@@ -59,8 +62,8 @@ func (writer *OutputWriter) Execute(rel *Release) error {
 	}
 
 	defer func() {
-		if err := file.Close(); err != nil {
-			panic(err)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
 		}
 	}()
 
@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/injection"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type Publisher interface {
+	Publish(repository injection.Repository, rel *Release) error
+}
+
+type CheckedPublisher interface {
+	Check() error
+}
+
+type PublisherGroup []Publisher
+
+type PublisherSpec struct {
+	BaseURL    string        `json:"baseUrl"`
+	Repository string        `json:"repository"`
+	Remote     string        `json:"remote"`
+	Template   *FileTemplate `json:"template"`
+	Assets     []string      `json:"assets"`
+	Client     *http.Client  `json:"-"`
+}
+
+type FileTemplate struct {
+	*template.Template
+}
+
+type apiClient struct {
+	*http.Client
+	Header http.Header
+}
+
+func (group PublisherGroup) Check() error {
+	for _, publisher := range group {
+		if publisher, ok := publisher.(CheckedPublisher); ok {
+			if err := publisher.Check(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (group PublisherGroup) Publish(repository injection.Repository, rel *Release) error {
+	for _, publisher := range group {
+		if err := publisher.Publish(repository, rel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (group *PublisherGroup) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	err := json.Unmarshal(data, &items)
+	if err != nil {
+		return err
+	}
+
+	*group = nil
+	for _, item := range items {
+		tmp := &struct {
+			Type string `json:"type"`
+		}{}
+
+		if err = json.Unmarshal(item, tmp); err != nil {
+			return err
+		}
+
+		var publisher Publisher
+		switch tmp.Type {
+		case "github":
+			publisher = &GitHubPublisher{}
+		case "gitlab":
+			publisher = &GitLabPublisher{}
+		case "gitea":
+			publisher = &GiteaPublisher{}
+		default:
+			return fmt.Errorf("unrecognized publisher type \"%s\"", tmp.Type)
+		}
+
+		if err = json.Unmarshal(item, publisher); err != nil {
+			return err
+		}
+
+		*group = append(*group, publisher)
+	}
+
+	return nil
+}
+
+func (spec *PublisherSpec) ReadRepository(repository injection.Repository) (string, error) {
+	if spec.Repository != "" {
+		return spec.Repository, nil
+	}
+
+	return ReadRemotePath(repository, spec.Remote)
+}
+
+func (spec *PublisherSpec) RenderBody(rel *Release) (string, error) {
+	tmpl := DefaultChangelogOutput.Template
+	if spec.Template != nil {
+		tmpl = spec.Template.Template
+	}
+
+	str := &strings.Builder{}
+	err := tmpl.Execute(str, rel)
+	return str.String(), err
+}
+
+func RenderString(str string, rel *Release) (string, error) {
+	tmpl, err := template.New("string").Parse(str)
+	if err != nil {
+		return "", err
+	}
+
+	builder := &strings.Builder{}
+	err = tmpl.Execute(builder, rel)
+	return builder.String(), err
+}
+
+func (spec *PublisherSpec) FindAssets() ([]string, error) {
+	var assets []string
+	for _, pattern := range spec.Assets {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		assets = append(assets, matches...)
+	}
+
+	return assets, nil
+}
+
+func (spec *PublisherSpec) newClient(header http.Header) *apiClient {
+	client := spec.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &apiClient{Client: client, Header: header}
+}
+
+func (client *apiClient) Do(method string, url string, body any, result any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	return client.DoRaw(method, url, reader, "application/json", result)
+}
+
+func (client *apiClient) Find(url string, result any) (bool, error) {
+	status, err := client.Do(http.MethodGet, url, nil, result)
+	if status == http.StatusNotFound {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (client *apiClient) DoRaw(method string, url string, body io.Reader, contentType string, result any) (int, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return 0, err
+	}
+
+	for key, values := range client.Header {
+		req.Header[key] = values
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	res, err := client.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("%s %s responded with %d: %s", method, url, res.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if result != nil && len(data) > 0 {
+		err = json.Unmarshal(data, result)
+	}
+
+	return res.StatusCode, err
+}
+
+func (tmpl *FileTemplate) UnmarshalJSON(data []byte) error {
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	tmpl.Template, err = template.ParseFiles(name)
+	return err
+}
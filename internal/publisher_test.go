@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIClient_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &apiClient{Client: server.Client()}
+	found, err := client.Find(server.URL+"/releases/tags/v1.0.0", nil)
+	if found || err != nil {
+		t.Fatalf(`(*apiClient).Find(...) = (%v, %v), expected (false, <nil>)`, found, err)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete} {
+		if status, err := client.Do(method, server.URL+"/releases", nil, nil); status != http.StatusNotFound || err == nil {
+			t.Fatalf(`(*apiClient).Do("%s", ...) = (%d, %v), expected a 404 to be an error`, method, status, err)
+		}
+	}
+}
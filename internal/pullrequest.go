@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"errors"
+	"github.com/bajankristof/relgen/internal/injection"
+	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"regexp"
+	"strings"
+)
+
+const DefaultPullRequestBranch = "release/next"
+
+var ReleaseFooterRegex = regexp.MustCompile("(?mi)" + ReleaseFooter + ": (?P<version>\\S+)\\s*$")
+
+var ErrNoPullRequestPublisher = errors.New("none of the publishers support pull requests")
+
+type PullRequestSpec struct {
+	Branch   string        `json:"branch"`
+	Title    string        `json:"title"`
+	Template *FileTemplate `json:"template"`
+}
+
+type PullRequest struct {
+	Head  string
+	Base  string
+	Title string
+	Body  string
+}
+
+type PullRequestPublisher interface {
+	PublishPullRequest(repository injection.Repository, rel *Release, pr *PullRequest) error
+}
+
+func (spec *PullRequestSpec) BranchName() plumbing.ReferenceName {
+	if spec.Branch == "" {
+		return plumbing.NewBranchReferenceName(DefaultPullRequestBranch)
+	}
+
+	return plumbing.NewBranchReferenceName(spec.Branch)
+}
+
+func (spec *PullRequestSpec) RenderTitle(rel *Release) (string, error) {
+	title := spec.Title
+	if title == "" {
+		title = DefaultCommitMessage
+	}
+
+	str, err := RenderString(title, rel)
+	return strings.TrimSpace(str), err
+}
+
+func (spec *PullRequestSpec) RenderBody(rel *Release) (string, error) {
+	tmpl := DefaultChangelogOutput.Template
+	if spec.Template != nil {
+		tmpl = spec.Template.Template
+	}
+
+	str := &strings.Builder{}
+	err := tmpl.Execute(str, rel)
+	return str.String(), err
+}
+
+func (group PublisherGroup) PublishPullRequest(repository injection.Repository, rel *Release, pr *PullRequest) error {
+	published := false
+	for _, publisher := range group {
+		if publisher, ok := publisher.(PullRequestPublisher); ok {
+			if err := publisher.PublishPullRequest(repository, rel, pr); err != nil {
+				return err
+			}
+
+			published = true
+		}
+	}
+
+	if !published {
+		return ErrNoPullRequestPublisher
+	}
+
+	return nil
+}
+
+func (builder *ReleaseBuilder) ReadMergedRelease() (*semver.Version, error) {
+	head, err := builder.ReadHead()
+	if err != nil || head == nil {
+		return nil, err
+	}
+
+	commits := []*object.Commit{head}
+	if head.NumParents() > 1 {
+		parent, err := builder.Repository.CommitObject(head.ParentHashes[1])
+		if err != nil {
+			return nil, err
+		}
+
+		commits = append(commits, parent)
+	}
+
+	for _, commit := range commits {
+		match := ReleaseFooterRegex.FindStringSubmatch(commit.Message)
+		if match != nil {
+			return semver.NewVersion(match[1])
+		}
+	}
+
+	return nil, nil
+}
+
+func (builder *ReleaseBuilder) CheckoutReleaseBranch() (func() error, error) {
+	worktree, err := builder.Repository.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := builder.Repository.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	restore := func() error {
+		if head.Name().IsBranch() {
+			return worktree.Checkout(&git.CheckoutOptions{Branch: head.Name()})
+		}
+
+		return worktree.Checkout(&git.CheckoutOptions{Hash: head.Hash()})
+	}
+
+	branch := builder.Config.PullRequest.BranchName()
+	err = worktree.Checkout(&git.CheckoutOptions{Branch: branch})
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return restore, worktree.Checkout(&git.CheckoutOptions{Branch: branch, Hash: head.Hash(), Create: true})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return restore, worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset})
+}
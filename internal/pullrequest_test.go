@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"encoding/json"
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/bajankristof/relgen/internal/semver"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReleaseBuilder_ReadMergedRelease(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	base := mocking.CommitFiles(repo, "feat: foo", nil)
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+
+	vsn, err := builder.ReadMergedRelease()
+	if err != nil || vsn != nil {
+		t.Fatalf("(*ReleaseBuilder(%v)).ReadMergedRelease() = (%v, %v), expected (<nil>, <nil>)", builder, vsn, err)
+	}
+
+	release := mocking.CommitFiles(repo, "chore(release): 1.1.0\n\nRelgen-Release: 1.1.0", nil)
+	mocking.CommitFiles(repo, "Merge pull request #3 from owner/release/next", nil, base, release)
+	vsn, err = builder.ReadMergedRelease()
+	if err != nil || vsn == nil || vsn.String() != "1.1.0" {
+		t.Fatalf("(*ReleaseBuilder(%v)).ReadMergedRelease() = (%v, %v), expected 1.1.0 from the merged pull request", builder, vsn, err)
+	}
+
+	mocking.CommitFiles(repo, "chore(release): 1.2.0 (#4)\n\n* chore(release): 1.2.0\n\nRelgen-Release: 1.2.0", nil)
+	vsn, err = builder.ReadMergedRelease()
+	if err != nil || vsn == nil || vsn.String() != "1.2.0" {
+		t.Fatalf("(*ReleaseBuilder(%v)).ReadMergedRelease() = (%v, %v), expected 1.2.0 from the squashed pull request", builder, vsn, err)
+	}
+}
+
+func TestReleaseBuilder_CheckoutReleaseBranch(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	mocking.CommitFiles(repo, "feat: foo", nil)
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+
+	for i := 0; i < 2; i++ {
+		restore, err := builder.CheckoutReleaseBranch()
+		head, _ := repo.Head()
+		if err != nil || head.Name() != "refs/heads/release/next" {
+			t.Fatalf("(*ReleaseBuilder(%v)).CheckoutReleaseBranch() = %v, expected to check out release/next, got %v", builder, err, head)
+		}
+
+		mocking.CommitFiles(repo, "chore(release): 1.0.0", nil)
+		err = restore()
+		head, _ = repo.Head()
+		if err != nil || head.Name() != "refs/heads/master" {
+			t.Fatalf("restore() = %v, expected to check out master, got %v", err, head)
+		}
+	}
+
+	branch, _ := repo.Reference("refs/heads/release/next", false)
+	commit, _ := repo.CommitObject(branch.Hash())
+	if commit.NumParents() != 1 || commit.Message != "chore(release): 1.0.0" {
+		t.Fatalf("(*ReleaseBuilder(%v)).CheckoutReleaseBranch(), expected release/next to be reset to master, got %v", builder, commit)
+	}
+}
+
+func TestPublisherGroup_PublishPullRequest(t *testing.T) {
+	var requests []string
+	var payloads []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		payload := map[string]any{}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/pulls" && len(requests) > 2:
+			_, _ = w.Write([]byte(`[{"number": 5}]`))
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/merge_requests"):
+			_, _ = w.Write([]byte(`[{"iid": 9}]`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "secret")
+	t.Setenv("GITLAB_TOKEN", "secret")
+
+	vsn, _ := semver.NewVersion("1.1.0")
+	rel := NewRelease(vsn)
+	pr := &PullRequest{Head: "release/next", Base: "main", Title: "chore(release): 1.1.0", Body: "## 1.1.0"}
+	github := &GitHubPublisher{PublisherSpec: PublisherSpec{BaseURL: server.URL, Repository: "owner/repo"}}
+	repo := mocking.NewMemoryRepository()
+
+	group := PublisherGroup{github}
+	if err := group.PublishPullRequest(repo, rel, pr); err != nil {
+		t.Fatalf(`(PublisherGroup(%v)).PublishPullRequest(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	}
+
+	if err := group.PublishPullRequest(repo, rel, pr); err != nil {
+		t.Fatalf(`(PublisherGroup(%v)).PublishPullRequest(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	}
+
+	expect := []string{
+		"GET /repos/owner/repo/pulls?base=main&head=owner%3Arelease%2Fnext&state=open",
+		"POST /repos/owner/repo/pulls?",
+		"GET /repos/owner/repo/pulls?base=main&head=owner%3Arelease%2Fnext&state=open",
+		"PATCH /repos/owner/repo/pulls/5?",
+	}
+
+	switch true {
+	case strings.Join(requests, ",") != strings.Join(expect, ","):
+		t.Fatalf(`(PublisherGroup(%v)).PublishPullRequest(...), expected requests %v, got %v`, group, expect, requests)
+	case payloads[1]["head"] != "release/next" || payloads[1]["body"] != "## 1.1.0":
+		t.Fatalf(`(PublisherGroup(%v)).PublishPullRequest(...), expected to open the pull request, got %v`, group, payloads[1])
+	}
+
+	requests, payloads = nil, nil
+	group = PublisherGroup{&GitLabPublisher{PublisherSpec: PublisherSpec{BaseURL: server.URL, Repository: "group/repo"}}}
+	if err := group.PublishPullRequest(repo, rel, pr); err != nil {
+		t.Fatalf(`(PublisherGroup(%v)).PublishPullRequest(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	}
+
+	if requests[1] != "PUT /projects/group%2Frepo/merge_requests/9?" || payloads[1]["description"] != "## 1.1.0" {
+		t.Fatalf(`(PublisherGroup(%v)).PublishPullRequest(...), expected to update the merge request, got %v %v`, group, requests, payloads)
+	}
+
+	group = PublisherGroup{&GiteaPublisher{}}
+	if err := group.PublishPullRequest(repo, rel, pr); err != ErrNoPullRequestPublisher {
+		t.Fatalf(`(PublisherGroup(%v)).PublishPullRequest(...) = %v, expected error to be %v`, group, err, ErrNoPullRequestPublisher)
+	}
+}
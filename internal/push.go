@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"os"
+)
+
+const DefaultPushUsername = "x-access-token"
+
+var PushTokenEnv = []string{"RELGEN_GIT_TOKEN", "GITHUB_TOKEN", "GITLAB_TOKEN", "GITEA_TOKEN"}
+
+var ErrRemoteMoved = errors.New("the remote branch has moved since the release was computed")
+
+type PushSpec struct {
+	Remote   string `json:"remote"`
+	Branch   string `json:"branch"`
+	Username string `json:"username"`
+}
+
+func (spec *PushSpec) remote() string {
+	if spec.Remote == "" {
+		return DefaultRemote
+	}
+
+	return spec.Remote
+}
+
+func (spec *PushSpec) Auth(url string) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, err
+	}
+
+	switch endpoint.Protocol {
+	case "http", "https":
+		username := spec.Username
+		if username == "" {
+			username = DefaultPushUsername
+		}
+
+		for _, env := range PushTokenEnv {
+			if token := os.Getenv(env); token != "" {
+				return &githttp.BasicAuth{Username: username, Password: token}, nil
+			}
+		}
+	case "ssh":
+		user := endpoint.User
+		if user == "" {
+			user = "git"
+		}
+
+		return ssh.NewSSHAgentAuth(user)
+	}
+
+	return nil, nil
+}
+
+func (builder *ReleaseBuilder) ReadBranch() (plumbing.ReferenceName, error) {
+	if builder.Config.Push.Branch != "" {
+		return plumbing.NewBranchReferenceName(builder.Config.Push.Branch), nil
+	}
+
+	head, err := builder.Repository.Head()
+	if err != nil {
+		return "", err
+	}
+
+	if !head.Name().IsBranch() {
+		return "", errors.New("HEAD is detached, set push.branch to push the release commit")
+	}
+
+	return head.Name(), nil
+}
+
+func (builder *ReleaseBuilder) Push(base plumbing.Hash, refs []plumbing.ReferenceName) error {
+	if len(refs) < 1 {
+		return nil
+	}
+
+	remote, auth, err := builder.openRemote()
+	if err != nil {
+		return err
+	}
+
+	head, err := builder.Repository.Head()
+	if err != nil {
+		return err
+	}
+
+	var specs []config.RefSpec
+	for _, ref := range refs {
+		src := ref.String()
+		if ref.IsBranch() {
+			if err = builder.checkRemoteBranch(remote, auth, ref, base); err != nil {
+				return err
+			}
+
+			src = head.Hash().String()
+		}
+
+		specs = append(specs, config.RefSpec(src+":"+ref.String()))
+	}
+
+	return push(remote, auth, specs)
+}
+
+func (builder *ReleaseBuilder) ForcePush(branch plumbing.ReferenceName) error {
+	remote, auth, err := builder.openRemote()
+	if err != nil {
+		return err
+	}
+
+	return push(remote, auth, []config.RefSpec{config.RefSpec("+" + branch.String() + ":" + branch.String())})
+}
+
+func (builder *ReleaseBuilder) openRemote() (*git.Remote, transport.AuthMethod, error) {
+	remote, err := builder.Repository.Remote(builder.Config.Push.remote())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) < 1 {
+		return nil, nil, errors.New("remote \"" + builder.Config.Push.remote() + "\" has no url")
+	}
+
+	auth, err := builder.Config.Push.Auth(urls[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return remote, auth, nil
+}
+
+func (builder *ReleaseBuilder) checkRemoteBranch(remote *git.Remote, auth transport.AuthMethod, branch plumbing.ReferenceName, base plumbing.Hash) error {
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if ref.Name() != branch || ref.Hash() == base {
+			continue
+		}
+
+		commit, err := builder.Repository.CommitObject(ref.Hash())
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return fmt.Errorf("%w (%s is at %s)", ErrRemoteMoved, branch.Short(), ref.Hash())
+		}
+
+		if err != nil {
+			return err
+		}
+
+		baseCommit, err := builder.Repository.CommitObject(base)
+		if err != nil {
+			return err
+		}
+
+		if ok, err := commit.IsAncestor(baseCommit); err != nil || !ok {
+			return fmt.Errorf("%w (%s is at %s)", ErrRemoteMoved, branch.Short(), ref.Hash())
+		}
+	}
+
+	return nil
+}
+
+func push(remote *git.Remote, auth transport.AuthMethod, specs []config.RefSpec) error {
+	err := remote.Push(&git.PushOptions{RemoteName: remote.Config().Name, RefSpecs: specs, Auth: auth})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+
+	return err
+}
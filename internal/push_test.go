@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"errors"
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestReleaseBuilder_Push(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	bare, err := git.PlainInit(dir, true)
+	if err != nil {
+		panic(err)
+	}
+
+	repo := mocking.NewMemoryRepository()
+	if _, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{dir}}); err != nil {
+		panic(err)
+	}
+
+	base := mocking.CommitFiles(repo, "feat: foo", map[string]string{"main.go": "package main\n"})
+	if err = repo.Push(&git.PushOptions{RefSpecs: []config.RefSpec{"refs/heads/master:refs/heads/master"}}); err != nil {
+		panic(err)
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{Commit: CommitSpec{Author: &CommitAuthor{Name: "Release Bot", Email: "bot@example.com"}}, ChangeSpec: DefaultChangeSpec})
+	vsn, _ := semver.NewVersion("1.0.0")
+	rel := NewRelease(vsn)
+	rel.Tag = "v1.0.0"
+	if _, err = builder.CommitOutputs(rel, nil); err != nil {
+		panic(err)
+	}
+
+	if err = builder.CreateTag(rel); err != nil {
+		panic(err)
+	}
+
+	branch, err := builder.ReadBranch()
+	if err != nil || branch != "refs/heads/master" {
+		t.Fatalf("(*ReleaseBuilder(%v)).ReadBranch() = (%v, %v), expected refs/heads/master", builder, branch, err)
+	}
+
+	err = builder.Push(base, []plumbing.ReferenceName{branch, plumbing.NewTagReferenceName(rel.Tag)})
+	head, _ := repo.Head()
+	remoteHead, _ := bare.Reference("refs/heads/master", false)
+	remoteTag, _ := bare.Reference("refs/tags/v1.0.0", false)
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).Push(...) = %v, expected error to be <nil>, got %v", builder, err, err)
+	case remoteHead == nil || remoteHead.Hash() != head.Hash():
+		t.Fatalf("(*ReleaseBuilder(%v)).Push(...) = %v, expected the remote branch to be at %s, got %v", builder, err, head.Hash(), remoteHead)
+	case remoteTag == nil || remoteTag.Hash() != head.Hash():
+		t.Fatalf("(*ReleaseBuilder(%v)).Push(...) = %v, expected the remote tag to be at %s, got %v", builder, err, head.Hash(), remoteTag)
+	}
+
+	moved := plumbing.NewHashReference("refs/heads/master", plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"))
+	if err = bare.Storer.SetReference(moved); err != nil {
+		panic(err)
+	}
+
+	mocking.CommitFiles(repo, "chore(release): 1.0.1", nil)
+	err = builder.Push(head.Hash(), []plumbing.ReferenceName{branch})
+	if !errors.Is(err, ErrRemoteMoved) {
+		t.Fatalf("(*ReleaseBuilder(%v)).Push(...) = %v, expected error to be %v", builder, err, ErrRemoteMoved)
+	}
+}
+
+func TestReleaseBuilder_PushWithoutRemoteURL(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = os.WriteFile(filepath.Join(dir, ".git", "config"), []byte("[remote \"origin\"]\n\tfetch = +refs/heads/*:refs/remotes/origin/*\n"), 0666); err != nil {
+		panic(err)
+	}
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	err = builder.ForcePush("refs/heads/release/next")
+	if err == nil || err.Error() != "remote \"origin\" has no url" {
+		t.Fatalf("(*ReleaseBuilder(%v)).ForcePush(...) = %v, expected a missing url error", builder, err)
+	}
+}
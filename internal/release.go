@@ -3,27 +3,39 @@ package internal
 import (
 	"github.com/bajankristof/relgen/internal/conventionalcommits"
 	"github.com/bajankristof/relgen/internal/semver"
+	"strings"
 	"time"
 )
 
 type Release struct {
-	bump      string
-	Version   *semver.Version `json:"version"`
-	Changelog Changelog       `json:"changelog"`
-	Date      time.Time       `json:"date"`
+	bump         string
+	Version      *semver.Version              `json:"version"`
+	Tag          string                       `json:"tag"`
+	Bump         string                       `json:"bump"`
+	Changelog    Changelog                    `json:"changelog"`
+	Issues       []*conventionalcommits.Issue `json:"issues"`
+	Contributors []*Contributor               `json:"contributors"`
+	Statistics   Statistics                   `json:"statistics"`
+	Date         time.Time                    `json:"date"`
 }
 
 func NewRelease(version *semver.Version) *Release {
 	return &Release{
-		bump:      semver.NONE,
-		Version:   semver.SelectLatest(semver.NewEmptyVersion(), version),
-		Changelog: Changelog{},
-		Date:      time.Now(),
+		bump:       semver.NONE,
+		Version:    semver.SelectLatest(semver.NewEmptyVersion(), version),
+		Bump:       semver.NONE,
+		Changelog:  Changelog{},
+		Statistics: NewStatistics(),
+		Date:       time.Now(),
 	}
 }
 
 func (rel *Release) Push(cc *conventionalcommits.ConventionalCommit, spec *ChangeSpec) *Release {
 	rel.Changelog[spec.Category] = append(rel.Changelog[spec.Category], cc)
+	rel.Statistics.Categories[spec.Category]++
+	for _, issue := range cc.Issues {
+		rel.PushIssue(issue)
+	}
 
 	if cc.IsBreakingChange() {
 		rel.bump = semver.MAJOR
@@ -34,10 +46,37 @@ func (rel *Release) Push(cc *conventionalcommits.ConventionalCommit, spec *Chang
 	return rel
 }
 
+func (rel *Release) PushIssue(issue *conventionalcommits.Issue) *Release {
+	for _, other := range rel.Issues {
+		if other.ID == issue.ID {
+			return rel
+		}
+	}
+
+	rel.Issues = append(rel.Issues, issue)
+	return rel
+}
+
+func (rel *Release) PushContributor(contributor *Contributor) *Release {
+	for _, other := range rel.Contributors {
+		if strings.EqualFold(other.Email, contributor.Email) {
+			return rel
+		}
+	}
+
+	rel.Contributors = append(rel.Contributors, contributor)
+	return rel
+}
+
 func (rel *Release) Close(tag string, metadata string) *Release {
 	rel.Version.BumpWithSpec(rel.bump)
 	rel.Version.WithPreReleaseTag(tag)
 	rel.Version.Metadata = metadata
+	rel.Bump = rel.bump
 	rel.bump = semver.NONE
 	return rel
 }
+
+func (rel *Release) CommitCount() int {
+	return rel.Statistics.Commits
+}
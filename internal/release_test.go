@@ -55,3 +55,14 @@ func TestRelease_Close(t *testing.T) {
 		t.Fatalf(`(*Release(%v)).Close("", ""), expected to reset bump, got "%s"`, rel, rel.bump)
 	}
 }
+
+func TestRelease_PushIssue(t *testing.T) {
+	spec := &ChangeSpec{Bump: semver.PATCH, Category: "Tests"}
+	rel := NewRelease(nil)
+	rel.Push(&conventionalcommits.ConventionalCommit{Issues: []*conventionalcommits.Issue{{ID: "#1"}, {ID: "#2"}}}, spec)
+	rel.Push(&conventionalcommits.ConventionalCommit{Issues: []*conventionalcommits.Issue{{ID: "#2"}, {ID: "#3"}}}, spec)
+
+	if len(rel.Issues) != 3 {
+		t.Fatalf(`(*Release(%v)).Push(...), expected 3 issues, got %v`, rel, rel.Issues)
+	}
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/injection"
+	"regexp"
+)
+
+const DefaultRemote = "origin"
+
+var RemoteURLRegex = regexp.MustCompile("^([a-z][a-z0-9+.-]*://)?([^@/]+@)?(?P<host>[^:/]+)(:[0-9]+)?[:/](?P<path>.+?)(\\.git)?/?$")
+
+func ParseRemoteURL(url string) (string, string, error) {
+	match := RemoteURLRegex.FindStringSubmatch(url)
+	if match == nil {
+		return "", "", fmt.Errorf("unrecognized remote url \"%s\"", url)
+	}
+
+	return match[RemoteURLRegex.SubexpIndex("host")], match[RemoteURLRegex.SubexpIndex("path")], nil
+}
+
+func ReadRemotePath(repository injection.Repository, name string) (string, error) {
+	if name == "" {
+		name = DefaultRemote
+	}
+
+	remote, err := repository.Remote(name)
+	if err != nil {
+		return "", err
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) < 1 {
+		return "", errors.New("remote \"" + name + "\" has no url")
+	}
+
+	_, path, err := ParseRemoteURL(urls[0])
+	return path, err
+}
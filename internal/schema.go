@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"time"
+)
+
+const SchemaVersion = 1
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatEnv  = "env"
+	FormatText = "text"
+)
+
+type ReleaseSchema struct {
+	Schema       int                          `json:"schema"`
+	Version      string                       `json:"version"`
+	Tag          string                       `json:"tag"`
+	Bump         string                       `json:"bump"`
+	PreRelease   bool                         `json:"prerelease"`
+	Date         time.Time                    `json:"date"`
+	Changelog    map[string][]*CommitSchema   `json:"changelog"`
+	Issues       []*conventionalcommits.Issue `json:"issues"`
+	Contributors []*Contributor               `json:"contributors"`
+	Statistics   Statistics                   `json:"statistics"`
+}
+
+type CommitSchema struct {
+	Hash        string              `json:"hash"`
+	ShortHash   string              `json:"shortHash"`
+	Author      *Contributor        `json:"author"`
+	Date        time.Time           `json:"date"`
+	Type        string              `json:"type"`
+	Scope       string              `json:"scope"`
+	Description string              `json:"description"`
+	Body        string              `json:"body"`
+	Footers     map[string][]string `json:"footers"`
+	Breaking    bool                `json:"breaking"`
+}
+
+func NewReleaseSchema(rel *Release) *ReleaseSchema {
+	schema := &ReleaseSchema{
+		Schema:       SchemaVersion,
+		Version:      rel.Version.String(),
+		Tag:          rel.Tag,
+		Bump:         rel.Bump,
+		PreRelease:   rel.Version.IsPreRelease(),
+		Date:         rel.Date,
+		Changelog:    map[string][]*CommitSchema{},
+		Issues:       append([]*conventionalcommits.Issue{}, rel.Issues...),
+		Contributors: append([]*Contributor{}, rel.Contributors...),
+		Statistics:   rel.Statistics,
+	}
+
+	for category, changes := range rel.Changelog {
+		for _, cc := range changes {
+			schema.Changelog[category] = append(schema.Changelog[category], NewCommitSchema(cc))
+		}
+	}
+
+	return schema
+}
+
+func NewCommitSchema(cc *conventionalcommits.ConventionalCommit) *CommitSchema {
+	hash := cc.Hash.String()
+	footers := cc.Footers
+	if footers == nil {
+		footers = map[string][]string{}
+	}
+
+	return &CommitSchema{
+		Hash:        hash,
+		ShortHash:   hash[:7],
+		Author:      &Contributor{Name: cc.Author.Name, Email: cc.Author.Email},
+		Date:        cc.Author.When,
+		Type:        cc.Type,
+		Scope:       cc.Scope,
+		Description: cc.Description,
+		Body:        cc.Body,
+		Footers:     footers,
+		Breaking:    cc.IsBreakingChange(),
+	}
+}
+
+func MarshalRelease(rel *Release) ([]byte, error) {
+	return json.Marshal(NewReleaseSchema(rel))
+}
+
+func FormatRelease(rel *Release, format string) (string, error) {
+	switch format {
+	case "", FormatJSON:
+		data, err := MarshalRelease(rel)
+		return string(data), err
+	case FormatYAML:
+		data, err := MarshalRelease(rel)
+		if err != nil {
+			return "", err
+		}
+
+		node := &yaml.Node{}
+		if err = yaml.Unmarshal(data, node); err != nil {
+			return "", err
+		}
+
+		resetStyle(node)
+		out := &bytes.Buffer{}
+		encoder := yaml.NewEncoder(out)
+		encoder.SetIndent(2)
+		if err = encoder.Encode(node); err != nil {
+			return "", err
+		}
+
+		return strings.TrimSuffix(out.String(), "\n"), nil
+	case FormatEnv:
+		lines := []string{fmt.Sprintf("RELGEN_SCHEMA=%d", SchemaVersion)}
+		for _, output := range CIOutputs(rel, len(rel.Changelog) > 0) {
+			lines = append(lines, "RELGEN_"+strings.ToUpper(output[0])+"="+output[1])
+		}
+
+		return strings.Join(lines, "\n"), nil
+	case FormatText:
+		return rel.Version.String(), nil
+	default:
+		return "", fmt.Errorf("unrecognized format \"%s\"", format)
+	}
+}
+
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
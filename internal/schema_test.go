@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"encoding/json"
+	"github.com/bajankristof/relgen/internal/conventionalcommits"
+	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSchemaRelease() *Release {
+	vsn, _ := semver.NewVersion("1.2.0")
+	rel := NewRelease(vsn)
+	rel.Tag, rel.Bump = "v1.2.0", semver.MINOR
+	commit := &object.Commit{
+		Hash:    plumbing.NewHash("3fa2b1c4d5e6f708192a3b4c5d6e7f8091a2b3c4"),
+		Author:  object.Signature{Name: "Jane Doe", Email: "jane@example.com", When: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		Message: "feat(api)!: add foo\n\nSome body\n\nRefs: #1",
+	}
+	rel.Push(&conventionalcommits.ConventionalCommit{
+		Commit:      commit,
+		Type:        "feat",
+		Scope:       "api",
+		Description: "add foo",
+		Body:        "Some body",
+		Footers:     map[string][]string{"refs": {"#1"}},
+		Exclamation: true,
+	}, &ChangeSpec{Bump: semver.MINOR, Category: "Features"})
+	return rel
+}
+
+func TestNewReleaseSchema(t *testing.T) {
+	rel := newSchemaRelease()
+	data, err := MarshalRelease(rel)
+	schema := map[string]interface{}{}
+	_ = json.Unmarshal(data, &schema)
+	changes, _ := schema["changelog"].(map[string]interface{})["Features"].([]interface{})
+	commit := map[string]interface{}{}
+	if len(changes) > 0 {
+		commit, _ = changes[0].(map[string]interface{})
+	}
+
+	keys := []string{"author", "body", "breaking", "date", "description", "footers", "hash", "scope", "shortHash", "type"}
+	switch true {
+	case err != nil:
+		t.Fatalf(`MarshalRelease(%v) = %v, expected error to be <nil>, got %v`, rel, err, err)
+	case schema["schema"] != float64(SchemaVersion) || schema["version"] != "1.2.0" || schema["tag"] != "v1.2.0":
+		t.Fatalf(`MarshalRelease(%v) = %s, expected the schema version, version and tag`, rel, data)
+	case len(commit) != len(keys):
+		t.Fatalf(`MarshalRelease(%v) = %s, expected the commits to carry exactly %v`, rel, data, keys)
+	case commit["shortHash"] != "3fa2b1c" || commit["breaking"] != true:
+		t.Fatalf(`MarshalRelease(%v) = %s, expected the short hash and the breaking flag`, rel, data)
+	}
+
+	for _, key := range keys {
+		if _, ok := commit[key]; !ok {
+			t.Fatalf(`MarshalRelease(%v) = %s, expected the commits to carry "%s"`, rel, data, key)
+		}
+	}
+}
+
+func TestFormatRelease(t *testing.T) {
+	rel := newSchemaRelease()
+
+	text, err := FormatRelease(rel, FormatText)
+	if err != nil || text != "1.2.0" {
+		t.Fatalf(`FormatRelease(%v, "text") = ("%s", %v), expected ("1.2.0", <nil>)`, rel, text, err)
+	}
+
+	env, err := FormatRelease(rel, FormatEnv)
+	if err != nil || !strings.HasPrefix(env, "RELGEN_SCHEMA=1\nRELGEN_VERSION=1.2.0\nRELGEN_TAG=v1.2.0\n") || !strings.Contains(env, "RELGEN_RELEASED=true") {
+		t.Fatalf(`FormatRelease(%v, "env") = ("%s", %v), expected KEY=value lines`, rel, env, err)
+	}
+
+	yml, err := FormatRelease(rel, FormatYAML)
+	if err != nil || !strings.HasPrefix(yml, "schema: 1\nversion: 1.2.0\ntag: v1.2.0\n") || !strings.Contains(yml, "      shortHash: 3fa2b1c\n") {
+		t.Fatalf(`FormatRelease(%v, "yaml") = ("%s", %v), expected a block style YAML document`, rel, yml, err)
+	}
+
+	if _, err = FormatRelease(rel, "xml"); err == nil {
+		t.Fatalf(`FormatRelease(%v, "xml"), expected an error`, rel)
+	}
+}
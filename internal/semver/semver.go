@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"github.com/coreos/go-semver/semver"
 	"github.com/go-git/go-git/v5/plumbing"
+	"regexp"
 	"strconv"
+	"strings"
 )
 
+const SanitizedIdentifierPrefix = "pre"
+
 const (
 	NONE  = "NONE"
 	MAJOR = "MAJOR"
@@ -15,6 +19,13 @@ const (
 	PATCH = "PATCH"
 )
 
+var (
+	IdentifierRegex        = regexp.MustCompile("^[0-9A-Za-z-]+$")
+	NumericIdentifierRegex = regexp.MustCompile("^[0-9]+$")
+	invalidIdentifierRegex = regexp.MustCompile("[^0-9A-Za-z-]+")
+	lenientVersionRegex    = regexp.MustCompile("^(v?)([0-9]+)(\\.[0-9]+)?(\\.[0-9]+)?([-+].*)?$")
+)
+
 type version = semver.Version
 
 type Version struct {
@@ -43,6 +54,21 @@ func NewVersion(version string) (*Version, error) {
 	return vsn, nil
 }
 
+func NewLenientVersion(version string) (*Version, error) {
+	match := lenientVersionRegex.FindStringSubmatch(version)
+	if match == nil {
+		return nil, fmt.Errorf("invalid version \"%s\"", version)
+	}
+
+	for i := 3; i <= 4; i++ {
+		if match[i] == "" {
+			match[i] = ".0"
+		}
+	}
+
+	return NewVersion(strings.Join(match[1:], ""))
+}
+
 func SelectLatest(versionA *Version, versionB *Version) *Version {
 	if versionB == nil {
 		return versionA
@@ -66,8 +92,54 @@ func SelectGreaterBumpSpec(bumpA string, bumpB string) string {
 	}
 }
 
+func CheckPreReleaseTag(tag string) error {
+	if tag == "" {
+		return nil
+	}
+
+	if !IdentifierRegex.MatchString(tag) {
+		return fmt.Errorf("invalid pre-release tag \"%s\", only alphanumerics and hyphens are allowed", tag)
+	}
+
+	if NumericIdentifierRegex.MatchString(tag) {
+		return fmt.Errorf("invalid pre-release tag \"%s\", numeric tags are reserved for pre-release numbers", tag)
+	}
+
+	return nil
+}
+
+func SanitizeIdentifier(str string) string {
+	if str == "" {
+		return ""
+	}
+
+	str = strings.Trim(invalidIdentifierRegex.ReplaceAllString(str, "-"), "-")
+	switch true {
+	case str == "":
+		return SanitizedIdentifierPrefix
+	case NumericIdentifierRegex.MatchString(str):
+		return SanitizedIdentifierPrefix + "-" + str
+	default:
+		return str
+	}
+}
+
+func CheckMetadata(metadata string) error {
+	if metadata == "" {
+		return nil
+	}
+
+	for _, identifier := range strings.Split(metadata, ".") {
+		if !IdentifierRegex.MatchString(identifier) {
+			return fmt.Errorf("invalid build-metadata \"%s\"", metadata)
+		}
+	}
+
+	return nil
+}
+
 func (vsn *Version) loadVersion(version string) error {
-	prefix := version[0] == 'v'
+	prefix := len(version) > 0 && version[0] == 'v'
 	if prefix {
 		vsn.prefix = prefix
 		version = version[1:]
@@ -108,6 +180,11 @@ func (vsn *Version) loadPreRelease() error {
 	}
 }
 
+func (vsn *Version) Clone() *Version {
+	v, preRelease := *vsn.version, *vsn.preRelease
+	return &Version{&v, &preRelease, vsn.reference, vsn.prefix}
+}
+
 func (vsn *Version) PreRelease() PreRelease {
 	return *vsn.preRelease
 }
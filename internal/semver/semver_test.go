@@ -156,6 +156,20 @@ func TestSelectGreaterBumpSpec(t *testing.T) {
 	}
 }
 
+func TestVersion_Clone(t *testing.T) {
+	vsn, _ := NewVersion("v1.2.3-rc.1")
+	clone := vsn.Clone()
+	clone.BumpWithSpec(MINOR)
+	clone.WithPreReleaseNumber(2)
+
+	switch true {
+	case vsn.String() != "v1.2.3-rc.1":
+		t.Fatalf(`(*Version(%v)).Clone() = %v, expected the original version to remain v1.2.3-rc.1`, vsn, clone)
+	case clone.String() != "v1.3.0-rc.2":
+		t.Fatalf(`(*Version(%v)).Clone() = %v, expected the clone to be v1.3.0-rc.2`, vsn, clone)
+	}
+}
+
 func TestVersion_PreRelease(t *testing.T) {
 	vsn := NewEmptyVersion()
 	preRelease := vsn.PreRelease()
@@ -427,3 +441,81 @@ func TestPreRelease_String(t *testing.T) {
 		t.Fatalf(`(*PreRelease(%v)).String(), expected to equal "%s", got "%s"`, pre, expect, str)
 	}
 }
+
+func TestCheckMetadata(t *testing.T) {
+	for _, metadata := range []string{"", "foo", "3fa2b1c.17", "build-1.x"} {
+		if err := CheckMetadata(metadata); err != nil {
+			t.Fatalf(`CheckMetadata("%s"), expected error to be <nil>, got %v`, metadata, err)
+		}
+	}
+
+	for _, metadata := range []string{".", "foo.", "foo bar", "foo/bar", "foo+bar"} {
+		if err := CheckMetadata(metadata); err == nil {
+			t.Fatalf(`CheckMetadata("%s"), expected error NOT to be <nil>`, metadata)
+		}
+	}
+}
+
+func TestCheckPreReleaseTag(t *testing.T) {
+	for _, tag := range []string{"", "beta", "rc-1", "feature-foo"} {
+		if err := CheckPreReleaseTag(tag); err != nil {
+			t.Fatalf(`CheckPreReleaseTag("%s"), expected error to be <nil>, got %v`, tag, err)
+		}
+	}
+
+	for _, tag := range []string{"feature/foo bar", "beta.1", "42", "foo_bar"} {
+		if err := CheckPreReleaseTag(tag); err == nil {
+			t.Fatalf(`CheckPreReleaseTag("%s"), expected error NOT to be <nil>`, tag)
+		}
+	}
+}
+
+func TestSanitizeIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"feature/foo bar":   "feature-foo-bar",
+		"--release/1.x_y--": "release-1-x-y",
+		"beta":              "beta",
+		"/":                 "pre",
+		"007":               "pre-007",
+		"v/007":             "v-007",
+		"":                  "",
+	}
+
+	for str, expect := range tests {
+		got := SanitizeIdentifier(str)
+		switch true {
+		case got != expect:
+			t.Fatalf(`SanitizeIdentifier("%s"), expected "%s", got "%s"`, str, expect, got)
+		case CheckPreReleaseTag(got) != nil:
+			t.Fatalf(`SanitizeIdentifier("%s") = "%s", expected a valid pre-release tag, got %v`, str, got, CheckPreReleaseTag(got))
+		}
+	}
+}
+
+func TestNewLenientVersion(t *testing.T) {
+	tests := map[string]string{
+		"1":           "1.0.0",
+		"1.2":         "1.2.0",
+		"v1.2":        "v1.2.0",
+		"1.2.3":       "1.2.3",
+		"1.2-beta.1":  "1.2.0-beta.1",
+		"1.2.3+build": "1.2.3+build",
+	}
+
+	for src, expect := range tests {
+		vsn, err := NewLenientVersion(src)
+		switch true {
+		case err != nil:
+			t.Fatalf(`NewLenientVersion("%s") = (%v, %v), expected error to be <nil>, got %v`, src, vsn, err, err)
+		case vsn.String() != expect:
+			t.Fatalf(`NewLenientVersion("%s") = (%v, %v), expected %s, got %v`, src, vsn, err, expect, vsn)
+		}
+	}
+
+	for _, src := range []string{"", "foo", "1.2.3.4", "release-1.2.3"} {
+		vsn, err := NewLenientVersion(src)
+		if err == nil {
+			t.Fatalf(`NewLenientVersion("%s") = (%v, %v), expected error NOT to be <nil>`, src, vsn, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bajankristof/relgen/internal/semver"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"time"
+)
+
+type Statistics struct {
+	Commits                int             `json:"commits"`
+	ConventionalCommits    int             `json:"conventionalCommits"`
+	NonConventionalCommits int             `json:"nonConventionalCommits"`
+	IgnoredCommits         int             `json:"ignoredCommits"`
+	Categories             map[string]int  `json:"categories"`
+	FilesChanged           int             `json:"filesChanged"`
+	Insertions             int             `json:"insertions"`
+	Deletions              int             `json:"deletions"`
+	PreviousVersion        *semver.Version `json:"previousVersion"`
+	PreviousTag            string          `json:"previousTag"`
+	PreviousDate           time.Time       `json:"previousDate"`
+	From                   time.Time       `json:"from"`
+	To                     time.Time       `json:"to"`
+	Span                   Duration        `json:"span"`
+}
+
+type Duration struct {
+	time.Duration
+}
+
+func NewStatistics() Statistics {
+	return Statistics{Categories: map[string]int{}}
+}
+
+func (stats *Statistics) PushCommit(commit *object.Commit, conventional bool) {
+	if conventional {
+		stats.ConventionalCommits++
+	} else {
+		stats.NonConventionalCommits++
+	}
+
+	stats.pushCommit(commit)
+}
+
+func (stats *Statistics) PushIgnored(commit *object.Commit) {
+	stats.IgnoredCommits++
+	stats.pushCommit(commit)
+}
+
+func (stats *Statistics) pushCommit(commit *object.Commit) {
+	stats.Commits++
+	when := commit.Committer.When
+	if stats.From.IsZero() || when.Before(stats.From) {
+		stats.From = when
+	}
+
+	if when.After(stats.To) {
+		stats.To = when
+	}
+
+	stats.Span = Duration{stats.To.Sub(stats.From)}
+}
+
+func (stats *Statistics) PushPrevious(version *semver.Version, commit *object.Commit) {
+	stats.PreviousVersion = version
+	stats.PreviousTag = version.Reference().Name().Short()
+	if commit == nil {
+		return
+	}
+
+	stats.PreviousDate = commit.Committer.When
+	if !stats.PreviousDate.IsZero() {
+		stats.From = stats.PreviousDate
+		stats.Span = Duration{stats.To.Sub(stats.From)}
+	}
+}
+
+func (stats *Statistics) PushChanges(changes object.Changes) error {
+	patch, err := changes.Patch()
+	if err != nil {
+		return err
+	}
+
+	stats.FilesChanged = len(changes)
+	for _, file := range patch.Stats() {
+		stats.Insertions += file.Addition
+		stats.Deletions += file.Deletion
+	}
+
+	return nil
+}
+
+func (builder *ReleaseBuilder) ReadStatistics(rel *Release, version *semver.Version) error {
+	var previous *object.Commit
+	if version != nil && version.Reference() != nil {
+		commit, err := builder.Repository.CommitObject(version.Reference().Hash())
+		if err != nil && !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return err
+		}
+
+		previous = commit
+		rel.Statistics.PushPrevious(version, previous)
+	}
+
+	head, err := builder.ReadHead()
+	if err != nil || head == nil {
+		return err
+	}
+
+	tree, err := builder.Repository.TreeObject(head.TreeHash)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var previousTree *object.Tree
+	if previous != nil {
+		previousTree, err = builder.Repository.TreeObject(previous.TreeHash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	changes, err := object.DiffTree(previousTree, tree)
+	if err != nil {
+		return err
+	}
+
+	return rel.Statistics.PushChanges(changes)
+}
+
+func (duration Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(duration.String())
+}
+
+func (duration *Duration) UnmarshalJSON(data []byte) error {
+	var str string
+	err := json.Unmarshal(data, &str)
+	if err != nil {
+		return err
+	}
+
+	duration.Duration, err = time.ParseDuration(str)
+	return err
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"encoding/json"
+	"github.com/bajankristof/relgen/internal/mocking"
+	"github.com/go-git/go-git/v5"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReleaseBuilder_BuildStatistics(t *testing.T) {
+	repo := mocking.NewMemoryRepository()
+	tagged := mocking.CommitFiles(repo, "chore: init", map[string]string{"a.txt": "a\nb\n"})
+	if _, err := repo.CreateTag("1.0.0", tagged, &git.CreateTagOptions{Tagger: mocking.Signature, Message: "1.0.0"}); err != nil {
+		t.Fatalf("CreateTag(...), expected error to be <nil>, got %v", err)
+	}
+
+	mocking.CommitFiles(repo, "feat: foo", map[string]string{"a.txt": "a\nc\nd\n", "b.txt": "b\n"})
+	mocking.CommitFiles(repo, "not so conventional", nil)
+	mocking.CommitFiles(repo, "fix: bar", map[string]string{"c.txt": "c\n"})
+	mocking.CommitFiles(repo, "fix: baz\n\nrelgen-off: true", nil)
+
+	builder := NewReleaseBuilder(repo, &Config{ChangeSpec: DefaultChangeSpec})
+	rel, err := builder.Build()
+	stats := rel.Statistics
+
+	switch true {
+	case err != nil:
+		t.Fatalf("(*ReleaseBuilder(%v)).Build() = (%v, %v), expected error to be <nil>, got %v", builder, rel, err, err)
+	case stats.Commits != 4 || stats.ConventionalCommits != 2 || stats.NonConventionalCommits != 1 || stats.IgnoredCommits != 1:
+		t.Fatalf("(*ReleaseBuilder(%v)).Build() = (%v, %v), expected 4 commits (2 conventional and 1 ignored), got %+v", builder, rel, err, stats)
+	case stats.Categories["Features"] != 1 || stats.Categories["Fixes"] != 1:
+		t.Fatalf("(*ReleaseBuilder(%v)).Build() = (%v, %v), expected a feature and a fix, got %v", builder, rel, err, stats.Categories)
+	case stats.FilesChanged != 3 || stats.Insertions != 4 || stats.Deletions != 1:
+		t.Fatalf("(*ReleaseBuilder(%v)).Build() = (%v, %v), expected 3 files, 4 insertions and 1 deletion, got %+v", builder, rel, err, stats)
+	case stats.PreviousTag != "1.0.0" || stats.PreviousVersion.String() != "1.0.0":
+		t.Fatalf("(*ReleaseBuilder(%v)).Build() = (%v, %v), expected previous version to be 1.0.0, got %v", builder, rel, err, stats.PreviousVersion)
+	case !stats.PreviousDate.Equal(mocking.Signature.When):
+		t.Fatalf("(*ReleaseBuilder(%v)).Build() = (%v, %v), expected previous date to be %v, got %v", builder, rel, err, mocking.Signature.When, stats.PreviousDate)
+	case rel.Version.String() != "1.1.0":
+		t.Fatalf("(*ReleaseBuilder(%v)).Build() = (%v, %v), expected version to be 1.1.0, got %v", builder, rel, err, rel.Version)
+	}
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	duration := Duration{36 * time.Hour}
+	bytes, err := json.Marshal(duration)
+	if err != nil || !strings.EqualFold(string(bytes), `"36h0m0s"`) {
+		t.Fatalf(`json.Marshal(%v) = (%s, %v), expected "36h0m0s"`, duration, bytes, err)
+	}
+}
@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bajankristof/relgen/internal/semver"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+const TagVersionGroup = "version"
+
+var (
+	TagVersionRegex         = "v?[0-9]+(?:\\.[0-9]+){0,2}(?:-[0-9A-Za-z.-]+)?(?:\\+[0-9A-Za-z.-]+)?"
+	tagVersionTemplateRegex = regexp.MustCompile("{{-?\\s*\\.Version\\s*-?}}")
+)
+
+type TagPattern struct {
+	*regexp.Regexp
+	source   string
+	template *template.Template
+}
+
+type TagTemplate struct {
+	*template.Template
+}
+
+type TagContext struct {
+	Version *semver.Version
+}
+
+func NewTagPattern(str string) (*TagPattern, error) {
+	pattern := &TagPattern{source: str}
+	if !strings.Contains(str, "{{") {
+		regex, err := regexp.Compile(str)
+		if err != nil {
+			return nil, err
+		}
+
+		if regex.SubexpIndex(TagVersionGroup) < 0 {
+			return nil, errors.New("tag pattern must contain a named \"version\" group (e.g.: ^release-(?P<version>.+)$)")
+		}
+
+		pattern.Regexp = regex
+		return pattern, nil
+	}
+
+	parts := tagVersionTemplateRegex.Split(str, -1)
+	if len(parts) != 2 || strings.Contains(parts[0], "{{") || strings.Contains(parts[1], "{{") {
+		return nil, errors.New("tag pattern template must contain exactly one {{.Version}} and no other actions")
+	}
+
+	tpl, err := template.New("tagPattern").Parse(str)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern.template = tpl
+	pattern.Regexp = regexp.MustCompile("^" + regexp.QuoteMeta(parts[0]) + "(?P<version>" + TagVersionRegex + ")" + regexp.QuoteMeta(parts[1]) + "$")
+	return pattern, nil
+}
+
+func (pattern *TagPattern) Parse(name string) (*semver.Version, error) {
+	match := pattern.FindStringSubmatch(name)
+	if match == nil {
+		return nil, errors.New("tag does not match the tag pattern")
+	}
+
+	return semver.NewLenientVersion(match[pattern.SubexpIndex(TagVersionGroup)])
+}
+
+func (pattern *TagPattern) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pattern.source)
+}
+
+func (pattern *TagPattern) UnmarshalJSON(bytes []byte) error {
+	str := ""
+	err := json.Unmarshal(bytes, &str)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := NewTagPattern(str)
+	if err != nil {
+		return err
+	}
+
+	*pattern = *tmp
+	return nil
+}
+
+func (tpl *TagTemplate) Format(version *semver.Version) (string, error) {
+	str := &strings.Builder{}
+	err := tpl.Execute(str, &TagContext{Version: version})
+	return str.String(), err
+}
+
+func (tpl *TagTemplate) UnmarshalJSON(bytes []byte) error {
+	str := ""
+	err := json.Unmarshal(bytes, &str)
+	if err != nil {
+		return err
+	}
+
+	tpl.Template, err = template.New("tagFormat").Parse(str)
+	return err
+}
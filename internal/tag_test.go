@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"github.com/bajankristof/relgen/internal/semver"
+	"testing"
+	"text/template"
+)
+
+func TestNewTagPattern(t *testing.T) {
+	pattern, err := NewTagPattern("release-{{.Version}}")
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewTagPattern("release-{{.Version}}") = (%v, %v), expected error to be <nil>, got %v`, pattern, err, err)
+	case pattern.template == nil:
+		t.Fatalf(`NewTagPattern("release-{{.Version}}") = (%v, %v), expected template NOT to be <nil>`, pattern, err)
+	case !pattern.MatchString("release-1.2"):
+		t.Fatalf(`NewTagPattern("release-{{.Version}}") = (%v, %v), expected to match "release-1.2"`, pattern, err)
+	case pattern.MatchString("1.2.3"):
+		t.Fatalf(`NewTagPattern("release-{{.Version}}") = (%v, %v), expected NOT to match "1.2.3"`, pattern, err)
+	}
+
+	pattern, err = NewTagPattern("(?i)^version_(?P<version>.+)$")
+	switch true {
+	case err != nil:
+		t.Fatalf(`NewTagPattern("(?i)^version_(?P<version>.+)$") = (%v, %v), expected error to be <nil>, got %v`, pattern, err, err)
+	case pattern.template != nil:
+		t.Fatalf(`NewTagPattern("(?i)^version_(?P<version>.+)$") = (%v, %v), expected template to be <nil>`, pattern, err)
+	}
+
+	for _, str := range []string{"^release-(.+)$", "{{.Version}}-{{.Version}}", "{{.Major}}", "(?P<version>"} {
+		pattern, err = NewTagPattern(str)
+		if err == nil {
+			t.Fatalf(`NewTagPattern("%s") = (%v, %v), expected error NOT to be <nil>`, str, pattern, err)
+		}
+	}
+}
+
+func TestTagPattern_Parse(t *testing.T) {
+	tests := map[string]string{
+		"release-{{.Version}}":          "release-1.2",
+		"{{ .Version }}":                "v2",
+		"(?i)^version_(?P<version>.+)$": "Version_1.2.3-beta.1",
+		"^(?P<version>[0-9.]+)-stable$": "3.1-stable",
+		"^[a-z]+/(?P<version>[0-9.]+)$": "foo/0.0.1",
+	}
+
+	expects := map[string]string{
+		"release-1.2":          "1.2.0",
+		"v2":                   "v2.0.0",
+		"Version_1.2.3-beta.1": "1.2.3-beta.1",
+		"3.1-stable":           "3.1.0",
+		"foo/0.0.1":            "0.0.1",
+	}
+
+	for str, name := range tests {
+		pattern, _ := NewTagPattern(str)
+		vsn, err := pattern.Parse(name)
+		switch true {
+		case err != nil:
+			t.Fatalf(`(*TagPattern(%v)).Parse("%s") = (%v, %v), expected error to be <nil>, got %v`, pattern, name, vsn, err, err)
+		case vsn.String() != expects[name]:
+			t.Fatalf(`(*TagPattern(%v)).Parse("%s") = (%v, %v), expected version to be %s, got %v`, pattern, name, vsn, err, expects[name], vsn)
+		}
+	}
+
+	pattern, _ := NewTagPattern("release-{{.Version}}")
+	vsn, err := pattern.Parse("1.2.3")
+	if err == nil {
+		t.Fatalf(`(*TagPattern(%v)).Parse("1.2.3") = (%v, %v), expected error NOT to be <nil>`, pattern, vsn, err)
+	}
+}
+
+func TestConfig_FormatTag(t *testing.T) {
+	vsn, _ := semver.NewVersion("1.2.3")
+	pattern, _ := NewTagPattern("release-{{.Version}}")
+	tests := []struct {
+		cfg    *Config
+		expect string
+	}{
+		{&Config{}, "1.2.3"},
+		{&Config{TagPattern: pattern}, "release-1.2.3"},
+		{&Config{TagPattern: pattern, TagFormat: &TagTemplate{template.Must(template.New("tagFormat").Parse("Version_{{.Version}}"))}}, "Version_1.2.3"},
+	}
+
+	for _, test := range tests {
+		tag, err := test.cfg.FormatTag(vsn)
+		switch true {
+		case err != nil:
+			t.Fatalf(`(*Config(%v)).FormatTag(%v) = (%v, %v), expected error to be <nil>, got %v`, test.cfg, vsn, tag, err, err)
+		case tag != test.expect:
+			t.Fatalf(`(*Config(%v)).FormatTag(%v) = (%v, %v), expected "%s", got "%s"`, test.cfg, vsn, tag, err, test.expect, tag)
+		}
+	}
+}
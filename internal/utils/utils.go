@@ -2,6 +2,7 @@ package utils
 
 import (
 	"regexp"
+	"strings"
 )
 
 type NamedRegexpGroupIter struct {
@@ -24,3 +25,72 @@ func (iter *NamedRegexpGroupIter) ForEach(subject string, callback func(group st
 
 	return true
 }
+
+func EditDistance(a string, b string) int {
+	source, target := []rune(a), []rune(b)
+	matrix := make([][]int, len(source)+1)
+	for i := range matrix {
+		matrix[i] = make([]int, len(target)+1)
+		matrix[i][0] = i
+	}
+
+	for j := range matrix[0] {
+		matrix[0][j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			matrix[i][j] = minInt(matrix[i-1][j]+1, matrix[i][j-1]+1, matrix[i-1][j-1]+cost)
+			if i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				matrix[i][j] = minInt(matrix[i][j], matrix[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return matrix[len(source)][len(target)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+
+	return result
+}
+
+func MatchGlob(pattern string, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		name = name[strings.LastIndex(name, "/")+1:]
+	}
+
+	expr := &strings.Builder{}
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	expr.WriteString("$")
+	matched, _ := regexp.MatchString(expr.String(), name)
+	return matched
+}
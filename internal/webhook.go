@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/sync/errgroup"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultWebhookRetries    = 2
+	DefaultWebhookTimeout    = 10 * time.Second
+	DefaultWebhookRetryDelay = time.Second
+)
+
+type WebhookGroup []*Webhook
+
+type Webhook struct {
+	URL        string            `json:"url"`
+	Method     string            `json:"method"`
+	Headers    map[string]string `json:"headers"`
+	Template   *FileTemplate     `json:"template"`
+	Retries    *int              `json:"retries"`
+	Timeout    Duration          `json:"timeout"`
+	RetryDelay Duration          `json:"retryDelay"`
+	Client     *http.Client      `json:"-"`
+}
+
+func (group WebhookGroup) Send(rel *Release) error {
+	errs := &errgroup.Group{}
+
+	for i := range group {
+		webhook := group[i]
+		errs.Go(func() error {
+			return webhook.Send(rel)
+		})
+	}
+
+	return errs.Wait()
+}
+
+func (group WebhookGroup) Print(w io.Writer, rel *Release) error {
+	for _, webhook := range group {
+		body, err := webhook.RenderBody(rel)
+		if err != nil {
+			return err
+		}
+
+		if _, err = fmt.Fprintf(w, "%s %s\n%s\n", webhook.method(), webhook.URL, body); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (webhook *Webhook) RenderBody(rel *Release) ([]byte, error) {
+	if webhook.Template == nil {
+		return MarshalRelease(rel)
+	}
+
+	body := &bytes.Buffer{}
+	err := webhook.Template.Execute(body, rel)
+	return body.Bytes(), err
+}
+
+func (webhook *Webhook) Send(rel *Release) error {
+	body, err := webhook.RenderBody(rel)
+	if err != nil {
+		return err
+	}
+
+	retries := DefaultWebhookRetries
+	if webhook.Retries != nil {
+		retries = *webhook.Retries
+	}
+
+	delay := webhook.RetryDelay.Duration
+	if delay == 0 {
+		delay = DefaultWebhookRetryDelay
+	}
+
+	for attempt := 0; ; attempt++ {
+		retry, err := webhook.send(body)
+		if err == nil || !retry || attempt >= retries {
+			return err
+		}
+
+		time.Sleep(delay * time.Duration(attempt+1))
+	}
+}
+
+func (webhook *Webhook) send(body []byte) (bool, error) {
+	req, err := http.NewRequest(webhook.method(), os.ExpandEnv(webhook.URL), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range webhook.Headers {
+		req.Header.Set(key, os.ExpandEnv(value))
+	}
+
+	client := webhook.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	timeout := webhook.Timeout.Duration
+	if timeout == 0 {
+		timeout = DefaultWebhookTimeout
+	}
+
+	client = &http.Client{Transport: client.Transport, Timeout: timeout}
+	res, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+
+	defer res.Body.Close()
+	data, _ := io.ReadAll(res.Body)
+	if res.StatusCode >= 400 {
+		retry := res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("webhook %s %s responded with %d: %s", webhook.method(), webhook.URL, res.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	return false, nil
+}
+
+func (webhook *Webhook) method() string {
+	if webhook.Method == "" {
+		return http.MethodPost
+	}
+
+	return strings.ToUpper(webhook.Method)
+}
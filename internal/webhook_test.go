@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/bajankristof/relgen/internal/semver"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWebhookGroup_Send(t *testing.T) {
+	var attempts int32
+	var body, auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		data, _ := io.ReadAll(r.Body)
+		body, auth = string(data), r.Header.Get("Authorization")
+	}))
+
+	defer server.Close()
+	t.Setenv("DEPLOY_TOKEN", "secret")
+
+	tmpl := path.Join(t.TempDir(), "slack.json")
+	if err := os.WriteFile(tmpl, []byte(`{"text": "Released {{.Tag}}"}`), 0777); err != nil {
+		panic(err)
+	}
+
+	group := WebhookGroup{}
+	err := json.Unmarshal([]byte(fmt.Sprintf(`[{
+		"url": "%s",
+		"headers": {"Authorization": "Bearer ${DEPLOY_TOKEN}"},
+		"template": "%s",
+		"timeout": "1s",
+		"retryDelay": "1ms"
+	}]`, server.URL, tmpl)), &group)
+	if err != nil {
+		t.Fatalf(`json.Unmarshal(...) = %v, expected error to be <nil>`, err)
+	}
+
+	vsn, _ := semver.NewVersion("1.2.0")
+	rel := NewRelease(vsn)
+	rel.Tag = "v1.2.0"
+	err = group.Send(rel)
+
+	switch true {
+	case err != nil:
+		t.Fatalf(`(WebhookGroup(%v)).Send(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	case attempts != 2:
+		t.Fatalf(`(WebhookGroup(%v)).Send(...) = %v, expected 2 attempts, got %d`, group, err, attempts)
+	case body != `{"text": "Released v1.2.0"}` || auth != "Bearer secret":
+		t.Fatalf(`(WebhookGroup(%v)).Send(...) = %v, expected the rendered payload, got "%s" (%s)`, group, err, body, auth)
+	}
+
+	retries := 0
+	attempts = -10
+	group[0].Retries = &retries
+	if err = group.Send(rel); err == nil || attempts != -9 {
+		t.Fatalf(`(WebhookGroup(%v)).Send(...) = %v, expected a single failed attempt, got %d`, group, err, attempts+10)
+	}
+}
+
+func TestWebhookGroup_Print(t *testing.T) {
+	group := WebhookGroup{{URL: "https://example.com/hooks/${TOKEN}", Method: "put"}}
+	rel := NewRelease(nil)
+	out := &bytes.Buffer{}
+	err := group.Print(out, rel)
+
+	switch true {
+	case err != nil:
+		t.Fatalf(`(WebhookGroup(%v)).Print(...) = %v, expected error to be <nil>, got %v`, group, err, err)
+	case !strings.HasPrefix(out.String(), "PUT https://example.com/hooks/${TOKEN}\n{\"schema\":1,"):
+		t.Fatalf(`(WebhookGroup(%v)).Print(...) = %v, expected the request to be printed, got "%s"`, group, err, out.String())
+	}
+}
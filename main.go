@@ -1,12 +1,20 @@
 package main
 
 import (
+	"fmt"
 	"github.com/bajankristof/relgen/cmd"
+	"os"
 )
 
 func main() {
 	err := cmd.Start()
-	if err != nil {
-		panic(err)
+	if err == nil {
+		return
 	}
+
+	if message := err.Error(); message != "" {
+		fmt.Fprintln(os.Stderr, "relgen: "+message)
+	}
+
+	os.Exit(cmd.ExitCode(err))
 }